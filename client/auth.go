@@ -0,0 +1,65 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/auth"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//AuthClient invoke the auth contract. The admin is the ONT ID of an account, signing with key number 1
+type AuthClient struct {
+	nativeClient
+}
+
+//NewAuthClient return a client of the auth contract on chain, sending transactions by options
+func NewAuthClient(chain common.Chain, options *Options) *AuthClient {
+	return &AuthClient{
+		nativeClient: newNativeClient(chain, utils.AuthContractAddress, options),
+	}
+}
+
+//AssignFuncsToRole allow role to call functions of contract
+func (this *AuthClient) AssignFuncsToRole(admin *sdk.Account, contract ontcommon.Address, role string, functions []string) (ontcommon.Uint256, error) {
+	return this.invoke(NewAccountSigner(admin), "assignFuncsToRole", &auth.FuncsToRoleParam{
+		ContractAddr: contract,
+		AdminOntID:   []byte(OntId(admin.Address)),
+		Role:         []byte(role),
+		FuncNames:    functions,
+		KeyNo:        1,
+	})
+}
+
+//AssignOntIDsToRole give role of contract to ontids
+func (this *AuthClient) AssignOntIDsToRole(admin *sdk.Account, contract ontcommon.Address, role string, ontids []string) (ontcommon.Uint256, error) {
+	params := &auth.OntIDsToRoleParam{
+		ContractAddr: contract,
+		AdminOntID:   []byte(OntId(admin.Address)),
+		Role:         []byte(role),
+		Persons:      [][]byte{},
+		KeyNo:        1,
+	}
+	for _, ontid := range ontids {
+		params.Persons = append(params.Persons, []byte(ontid))
+	}
+	return this.invoke(NewAccountSigner(admin), "assignOntIDsToRole", params)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+//Package client is the library behind the governance methods of ontology-tool. GovernanceClient, AuthClient,
+//OntIdClient and NativeTokenClient build, sign and send native contract transactions and read native contract
+//storage, returning typed values and errors. Gas and how transactions are sent are given by Options, clients do
+//not read the config or flags of the tool
+package client
+
+import (
+	"fmt"
+
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//NATIVE_VERSION is the version of native contract invocations
+const NATIVE_VERSION = byte(0)
+
+//Gas of transactions of clients created without options
+const (
+	DEFAULT_GAS_PRICE = 2500
+	DEFAULT_GAS_LIMIT = 20000
+)
+
+//Sender send the signed native transactions of clients to chain
+type Sender interface {
+	SendNativeTx(chain common.Chain, nativeTx *common.NativeTx) (ontcommon.Uint256, error)
+}
+
+//SenderFunc is a function used as Sender
+type SenderFunc func(chain common.Chain, nativeTx *common.NativeTx) (ontcommon.Uint256, error)
+
+func (this SenderFunc) SendNativeTx(chain common.Chain, nativeTx *common.NativeTx) (ontcommon.Uint256, error) {
+	return this(chain, nativeTx)
+}
+
+//Options of the transactions of a client
+type Options struct {
+	GasPrice uint64
+	GasLimit uint64
+	//Sender of transactions, default is PreExecSender
+	Sender Sender
+}
+
+//DefaultOptions return the options of clients created with nil options
+func DefaultOptions() *Options {
+	return &Options{
+		GasPrice: DEFAULT_GAS_PRICE,
+		GasLimit: DEFAULT_GAS_LIMIT,
+		Sender:   PreExecSender,
+	}
+}
+
+//PreExecSender build and pre-execute a transaction, and send it to chain if pre-execution succeeds within its
+//gas limit
+var PreExecSender = SenderFunc(func(chain common.Chain, nativeTx *common.NativeTx) (ontcommon.Uint256, error) {
+	tx, err := nativeTx.Build()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	preExec, err := common.PreExecTransaction(chain, tx, nativeTx)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	if preExec.Gas > nativeTx.GasLimit {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("estimated gas %d exceeds gas limit %d", preExec.Gas, nativeTx.GasLimit)
+	}
+	return chain.SendTransaction(tx)
+})
+
+//nativeClient invoke and query one native contract
+type nativeClient struct {
+	chain    common.Chain
+	contract ontcommon.Address
+	sender   Sender
+	//GasPrice of transactions
+	GasPrice uint64
+	//GasLimit of transactions
+	GasLimit uint64
+}
+
+//newNativeClient return a client of contract on chain, options are DefaultOptions if nil
+func newNativeClient(chain common.Chain, contract ontcommon.Address, options *Options) nativeClient {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	sender := options.Sender
+	if sender == nil {
+		sender = PreExecSender
+	}
+	return nativeClient{
+		chain:    chain,
+		contract: contract,
+		sender:   sender,
+		GasPrice: options.GasPrice,
+		GasLimit: options.GasLimit,
+	}
+}
+
+//newNativeTx return an unsigned invocation of method of the contract
+func (this *nativeClient) newNativeTx(method string, params []interface{}) *common.NativeTx {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return &common.NativeTx{
+		GasPrice: this.GasPrice,
+		GasLimit: this.GasLimit,
+		Version:  NATIVE_VERSION,
+		Contract: this.contract,
+		Method:   method,
+		Params:   params,
+	}
+}
+
+//invoke send a transaction calling method of the contract, signed by signer
+func (this *nativeClient) invoke(signer *Signer, method string, params ...interface{}) (ontcommon.Uint256, error) {
+	nativeTx := this.newNativeTx(method, params)
+	err := signer.sign(nativeTx)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, &TxError{Contract: this.contract, Method: method, Err: err}
+	}
+	txHash, err := this.sender.SendNativeTx(this.chain, nativeTx)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, &TxError{Contract: this.contract, Method: method, Err: err}
+	}
+	return txHash, nil
+}
+
+//preExec pre-execute an unsigned call of method of the contract and return its result
+func (this *nativeClient) preExec(method string, params ...interface{}) (*sdkcom.ResultItem, error) {
+	tx, err := this.newNativeTx(method, params).Build()
+	if err != nil {
+		return nil, &TxError{Contract: this.contract, Method: method, Err: err}
+	}
+	res, err := this.chain.PreExecTransaction(tx)
+	if err != nil {
+		return nil, &TxError{Contract: this.contract, Method: method, Err: err}
+	}
+	if res.State == 0 {
+		return nil, &TxError{Contract: this.contract, Method: method, Err: fmt.Errorf("pre-execute failed, state 0")}
+	}
+	return res.Result, nil
+}
+
+//getStorage return the raw storage value of key in the contract, empty if not found
+func (this *nativeClient) getStorage(item string, key []byte) ([]byte, error) {
+	value, err := this.chain.GetStorage(this.contract.ToHexString(), key)
+	if err != nil {
+		return nil, &StorageError{Contract: this.contract, Item: item, Err: err}
+	}
+	return value, nil
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/fakechain"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//recordSender keep the transactions it is given instead of sending them
+type recordSender struct {
+	sent []*common.NativeTx
+}
+
+func (this *recordSender) SendNativeTx(chain common.Chain, nativeTx *common.NativeTx) (ontcommon.Uint256, error) {
+	this.sent = append(this.sent, nativeTx)
+	return ontcommon.UINT256_EMPTY, nil
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *client.Options
+		gasPrice uint64
+		gasLimit uint64
+		err      string
+	}{
+		{name: "default options", gasPrice: client.DEFAULT_GAS_PRICE, gasLimit: client.DEFAULT_GAS_LIMIT},
+		{name: "gas of options", options: &client.Options{GasPrice: 0, GasLimit: 30000}, gasPrice: 0, gasLimit: 30000},
+		{name: "gas limit under pre-execution gas", options: &client.Options{GasPrice: 2500, GasLimit: 100},
+			err: "estimated gas 20000 exceeds gas limit 100"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := fakechain.New()
+			_, err := client.NewGovernanceClient(chain, test.options).CommitDpos(client.NewAccountSigner(sdk.NewAccount()))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) || len(chain.Sent()) != 0 {
+					t.Fatalf("error %v with %d transactions sent, should be %q", err, len(chain.Sent()), test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CommitDpos error:%s", err)
+			}
+			sent := chain.Sent()
+			if len(sent) != 1 || sent[0].Tx.GasPrice != test.gasPrice || sent[0].Tx.GasLimit != test.gasLimit {
+				t.Fatalf("%d transactions sent, should be one with gas price %d and gas limit %d", len(sent), test.gasPrice, test.gasLimit)
+			}
+		})
+	}
+}
+
+//TestConfigIgnored check that the client does not read the config of the tool
+func TestConfigIgnored(t *testing.T) {
+	defConfig := config.DefConfig
+	defer func() { config.DefConfig = defConfig }()
+	config.DefConfig = config.NewConfig()
+	config.DefConfig.DryRun = true
+	config.DefConfig.Export = "ignored.json"
+	config.DefConfig.GasLimit = 1
+
+	chain := fakechain.New()
+	_, err := client.NewGovernanceClient(chain, nil).CommitDpos(client.NewAccountSigner(sdk.NewAccount()))
+	if err != nil {
+		t.Fatalf("CommitDpos error:%s", err)
+	}
+	if len(chain.Sent()) != 1 {
+		t.Fatalf("%d transactions sent, should be 1 whatever the config of the tool", len(chain.Sent()))
+	}
+}
+
+func TestSender(t *testing.T) {
+	chain := fakechain.New()
+	sender := new(recordSender)
+	account := sdk.NewAccount()
+	to := sdk.NewAccount().Address
+	_, err := client.NewOngClient(chain, &client.Options{GasLimit: 20000, Sender: sender}).Transfer(client.NewAccountSigner(account), to, 100)
+	if err != nil {
+		t.Fatalf("Transfer error:%s", err)
+	}
+	if len(chain.Sent()) != 0 || len(sender.sent) != 1 {
+		t.Fatalf("%d transactions sent to chain and %d to sender, should all go to sender", len(chain.Sent()), len(sender.sent))
+	}
+	nativeTx := sender.sent[0]
+	if nativeTx.Contract != utils.OngContractAddress || nativeTx.Method != "transfer" || len(nativeTx.Signers) != 1 ||
+		nativeTx.Signers[0] != account {
+		t.Fatalf("sender got %s %s, should be ong transfer signed by the account", nativeTx.Contract.ToHexString(), nativeTx.Method)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	chain := fakechain.New()
+	chain.SetFailure("commitDpos", fmt.Errorf("commitDpos failed"))
+	gov := client.NewGovernanceClient(chain, nil)
+	unknownPeer := "02" + strings.Repeat("0", 64)
+	tests := []struct {
+		name  string
+		call  func() error
+		check func(err error) bool
+	}{
+		{
+			name: "failed pre-execution",
+			call: func() error {
+				_, err := gov.CommitDpos(client.NewAccountSigner(sdk.NewAccount()))
+				return err
+			},
+			check: func(err error) bool {
+				var txError *client.TxError
+				return errors.As(err, &txError) && txError.Method == "commitDpos"
+			},
+		},
+		{
+			name: "no signer",
+			call: func() error {
+				_, err := gov.CommitDpos(client.NewAccountSigner())
+				return err
+			},
+			check: func(err error) bool { return errors.Is(err, client.ErrNoSigner) },
+		},
+		{
+			name: "missing storage item",
+			call: func() error {
+				_, err := gov.GetVbftConfig()
+				return err
+			},
+			check: func(err error) bool {
+				var storageError *client.StorageError
+				return errors.As(err, &storageError) && storageError.Item == "vbftConfig"
+			},
+		},
+		{
+			name: "view not in storage",
+			call: func() error {
+				_, err := gov.GetPeerPoolMapOfView(3)
+				return err
+			},
+			check: func(err error) bool { return errors.Is(err, client.ErrViewNotFound) },
+		},
+		{
+			name: "peer not in pool",
+			call: func() error {
+				err := chain.SetGovernanceView(&governance.GovernanceView{View: 1})
+				if err != nil {
+					return err
+				}
+				err = chain.SetPeerPoolMap(1, &governance.PeerPoolMap{PeerPoolMap: make(map[string]*governance.PeerPoolItem)})
+				if err != nil {
+					return err
+				}
+				_, err = gov.GetPeerPoolItem(unknownPeer)
+				return err
+			},
+			check: func(err error) bool { return errors.Is(err, client.ErrPeerNotFound) },
+		},
+		{
+			name: "invalid peer public key",
+			call: func() error {
+				_, err := gov.InBlackList("invalid")
+				return err
+			},
+			check: func(err error) bool { return errors.Is(err, client.ErrInvalidPeerPubkey) },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.call()
+			if err == nil || !test.check(err) {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	accounts := []*sdk.Account{sdk.NewAccount(), sdk.NewAccount(), sdk.NewAccount()}
+	pubKeys := []keypair.PublicKey{accounts[0].PublicKey, accounts[1].PublicKey, accounts[2].PublicKey}
+	_, multiSignAddress, err := common.MultiSignAddress(pubKeys, 2, "")
+	if err != nil {
+		t.Fatalf("MultiSignAddress error:%s", err)
+	}
+	tests := []struct {
+		name    string
+		signer  *client.Signer
+		address ontcommon.Address
+		err     error
+	}{
+		{name: "account signer", signer: client.NewAccountSigner(accounts[1], accounts[0]), address: accounts[1].Address},
+		{name: "multi signer", signer: client.NewMultiSigner(pubKeys, 2, accounts[:2]), address: multiSignAddress},
+		{name: "multi signer without accounts", signer: client.NewMultiSigner(pubKeys, 2, nil), address: multiSignAddress},
+		{name: "no account", signer: client.NewAccountSigner(), err: client.ErrNoSigner},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := test.signer.Address()
+			if !errors.Is(err, test.err) || address != test.address {
+				t.Fatalf("address %s error %v, should be %s error %v", address.ToBase58(), err, test.address.ToBase58(), test.err)
+			}
+		})
+	}
+}
+
+func TestBalanceOf(t *testing.T) {
+	chain := fakechain.New()
+	address := sdk.NewAccount().Address
+	chain.SetBalance(utils.OntContractAddress, address, 1000)
+	tests := []struct {
+		name   string
+		client *client.NativeTokenClient
+		want   uint64
+	}{
+		{name: "ont", client: client.NewOntClient(chain, nil), want: 1000},
+		{name: "ong", client: client.NewOngClient(chain, nil), want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			balance, err := test.client.BalanceOf(address)
+			if err != nil || balance != test.want {
+				t.Fatalf("balance %d error %v, should be %d", balance, err, test.want)
+			}
+		})
+	}
+}
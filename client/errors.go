@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+var (
+	//ErrInvalidPeerPubkey is returned when a peer public key is not hex encoded
+	ErrInvalidPeerPubkey = errors.New("invalid peer public key")
+	//ErrPeerNotFound is returned when a peer is not in the peer pool
+	ErrPeerNotFound = errors.New("peer not found in peer pool")
+	//ErrViewNotFound is returned when the peer pool of a view is not in storage, the contract keeps recent views only
+	ErrViewNotFound = errors.New("peer pool of view not found")
+	//ErrNoSigner is returned when a signer has no account to sign or to take the address from
+	ErrNoSigner = errors.New("no signer account")
+	//ErrLengthMismatch is returned when lists which go together have different lengths
+	ErrLengthMismatch = errors.New("input length mismatch")
+)
+
+//StorageError is returned when an item of native contract storage can not be read or decoded
+type StorageError struct {
+	Contract ontcommon.Address
+	Item     string
+	Err      error
+}
+
+func (this *StorageError) Error() string {
+	return fmt.Sprintf("%s %s error:%s", common.ContractName(this.Contract), this.Item, this.Err)
+}
+
+func (this *StorageError) Unwrap() error {
+	return this.Err
+}
+
+//TxError is returned when a native contract transaction can not be signed, pre-executed or sent
+type TxError struct {
+	Contract ontcommon.Address
+	Method   string
+	Err      error
+}
+
+func (this *TxError) Error() string {
+	return fmt.Sprintf("%s %s error:%s", common.ContractName(this.Contract), this.Method, this.Err)
+}
+
+func (this *TxError) Unwrap() error {
+	return this.Err
+}
+
+//decodePeerPubkey decode a hex encoded peer public key used in storage keys
+func decodePeerPubkey(peerPubkey string) ([]byte, error) {
+	data, err := hex.DecodeString(peerPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidPeerPubkey, peerPubkey, err)
+	}
+	return data, nil
+}
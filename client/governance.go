@@ -0,0 +1,573 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/serialization"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//GovernanceClient invoke and query the governance contract
+type GovernanceClient struct {
+	nativeClient
+}
+
+//NewGovernanceClient return a client of the governance contract on chain, sending transactions by options
+func NewGovernanceClient(chain common.Chain, options *Options) *GovernanceClient {
+	return &GovernanceClient{
+		nativeClient: newNativeClient(chain, utils.GovernanceContractAddress, options),
+	}
+}
+
+//RegisterCandidate register peerPubkey as candidate owned by the signer address, with initPos ONT staked
+func (this *GovernanceClient) RegisterCandidate(signer *Signer, peerPubkey string, initPos uint32) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "registerCandidate", &governance.RegisterCandidateParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+		InitPos:    initPos,
+	})
+}
+
+//RegisterCandidateWithOntId register peerPubkey as candidate owned by user, authorized by the ONT ID of ontid
+//with key number 1. Both accounts sign
+func (this *GovernanceClient) RegisterCandidateWithOntId(ontid *sdk.Account, user *sdk.Account, peerPubkey string, initPos uint32) (ontcommon.Uint256, error) {
+	return this.invoke(NewAccountSigner(user, ontid), "registerCandidate", &governance.RegisterCandidateParam{
+		PeerPubkey: peerPubkey,
+		Address:    user.Address,
+		InitPos:    initPos,
+		Caller:     []byte(OntId(ontid.Address)),
+		KeyNo:      1,
+	})
+}
+
+//UnRegisterCandidate cancel the registration of peerPubkey before it is approved
+func (this *GovernanceClient) UnRegisterCandidate(signer *Signer, peerPubkey string) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "unRegisterCandidate", &governance.UnRegisterCandidateParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+	})
+}
+
+//ApproveCandidate approve a registered candidate, signed by the governance admin
+func (this *GovernanceClient) ApproveCandidate(signer *Signer, peerPubkey string) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "approveCandidate", &governance.ApproveCandidateParam{
+		PeerPubkey: peerPubkey,
+	})
+}
+
+//RejectCandidate reject a registered candidate, signed by the governance admin
+func (this *GovernanceClient) RejectCandidate(signer *Signer, peerPubkey string) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "rejectCandidate", &governance.RejectCandidateParam{
+		PeerPubkey: peerPubkey,
+	})
+}
+
+//ChangeMaxAuthorization change the max authorization of a peer owned by the signer address
+func (this *GovernanceClient) ChangeMaxAuthorization(signer *Signer, peerPubkey string, maxAuthorize uint32) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "changeMaxAuthorization", &governance.ChangeMaxAuthorizationParam{
+		PeerPubkey:   peerPubkey,
+		Address:      address,
+		MaxAuthorize: maxAuthorize,
+	})
+}
+
+//SetFeePercentage set the fee percentage a peer owned by the signer address takes from its own and from
+//authorized stake
+func (this *GovernanceClient) SetFeePercentage(signer *Signer, peerPubkey string, peerCost, stakeCost uint32) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "setFeePercentage", &governance.SetFeePercentageParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+		PeerCost:   peerCost,
+		StakeCost:  stakeCost,
+	})
+}
+
+//AddInitPos add pos to the init stake of a peer owned by the signer address
+func (this *GovernanceClient) AddInitPos(signer *Signer, peerPubkey string, pos uint32) (ontcommon.Uint256, error) {
+	return this.changeInitPos(signer, "addInitPos", peerPubkey, pos)
+}
+
+//ReduceInitPos reduce pos from the init stake of a peer owned by the signer address
+func (this *GovernanceClient) ReduceInitPos(signer *Signer, peerPubkey string, pos uint32) (ontcommon.Uint256, error) {
+	return this.changeInitPos(signer, "reduceInitPos", peerPubkey, pos)
+}
+
+func (this *GovernanceClient) changeInitPos(signer *Signer, method string, peerPubkey string, pos uint32) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, method, &governance.ChangeInitPosParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+		Pos:        pos,
+	})
+}
+
+//AuthorizeForPeer authorize posList[i] of the signer address to peerPubkeyList[i]
+func (this *GovernanceClient) AuthorizeForPeer(signer *Signer, peerPubkeyList []string, posList []uint32) (ontcommon.Uint256, error) {
+	return this.authorize(signer, "authorizeForPeer", peerPubkeyList, posList)
+}
+
+//UnAuthorizeForPeer cancel posList[i] the signer address authorized to peerPubkeyList[i]
+func (this *GovernanceClient) UnAuthorizeForPeer(signer *Signer, peerPubkeyList []string, posList []uint32) (ontcommon.Uint256, error) {
+	return this.authorize(signer, "unAuthorizeForPeer", peerPubkeyList, posList)
+}
+
+func (this *GovernanceClient) authorize(signer *Signer, method string, peerPubkeyList []string, posList []uint32) (ontcommon.Uint256, error) {
+	if len(peerPubkeyList) != len(posList) {
+		return ontcommon.UINT256_EMPTY, ErrLengthMismatch
+	}
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, method, &governance.AuthorizeForPeerParam{
+		Address:        address,
+		PeerPubkeyList: peerPubkeyList,
+		PosList:        posList,
+	})
+}
+
+//Withdraw withdraw unfrozen withdrawList[i] the signer address authorized to peerPubkeyList[i]
+func (this *GovernanceClient) Withdraw(signer *Signer, peerPubkeyList []string, withdrawList []uint32) (ontcommon.Uint256, error) {
+	if len(peerPubkeyList) != len(withdrawList) {
+		return ontcommon.UINT256_EMPTY, ErrLengthMismatch
+	}
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "withdraw", &governance.WithdrawParam{
+		Address:        address,
+		PeerPubkeyList: peerPubkeyList,
+		WithdrawList:   withdrawList,
+	})
+}
+
+//WithdrawOng withdraw the unbound ONG of the signer address
+func (this *GovernanceClient) WithdrawOng(signer *Signer) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "withdrawOng", &governance.WithdrawOngParam{
+		Address: address,
+	})
+}
+
+//WithdrawFee withdraw the split fee of the signer address
+func (this *GovernanceClient) WithdrawFee(signer *Signer) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "withdrawFee", &governance.WithdrawFeeParam{
+		Address: address,
+	})
+}
+
+//CommitDpos start a new consensus round, signed by the governance admin
+func (this *GovernanceClient) CommitDpos(signer *Signer) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "commitDpos")
+}
+
+//QuitNode quit a peer owned by the signer address
+func (this *GovernanceClient) QuitNode(signer *Signer, peerPubkey string) (ontcommon.Uint256, error) {
+	address, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "quitNode", &governance.QuitNodeParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+	})
+}
+
+//BlackNode put peers into the black list, signed by the governance admin
+func (this *GovernanceClient) BlackNode(signer *Signer, peerPubkeyList []string) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "blackNode", &governance.BlackNodeParam{
+		PeerPubkeyList: peerPubkeyList,
+	})
+}
+
+//WhiteNode remove a peer from the black list, signed by the governance admin
+func (this *GovernanceClient) WhiteNode(signer *Signer, peerPubkey string) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "whiteNode", &governance.WhiteNodeParam{
+		PeerPubkey: peerPubkey,
+	})
+}
+
+//UpdateConfig update the vbft config, signed by the governance admin
+func (this *GovernanceClient) UpdateConfig(signer *Signer, conf *governance.Configuration) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "updateConfig", conf)
+}
+
+//UpdateGlobalParam update the global params, signed by the governance admin
+func (this *GovernanceClient) UpdateGlobalParam(signer *Signer, globalParam *governance.GlobalParam) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "updateGlobalParam", globalParam)
+}
+
+//UpdateGlobalParam2 update the global params 2, signed by the governance admin
+func (this *GovernanceClient) UpdateGlobalParam2(signer *Signer, globalParam2 *governance.GlobalParam2) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "updateGlobalParam2", globalParam2)
+}
+
+//UpdateSplitCurve update the split curve, signed by the governance admin
+func (this *GovernanceClient) UpdateSplitCurve(signer *Signer, splitCurve *governance.SplitCurve) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "updateSplitCurve", splitCurve)
+}
+
+//SetPromisePos set the promise pos of a peer, signed by the governance admin
+func (this *GovernanceClient) SetPromisePos(signer *Signer, promisePos *governance.PromisePos) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "setPromisePos", promisePos)
+}
+
+//TransferPenalty transfer the penalty stake of a peer to address, signed by the governance admin
+func (this *GovernanceClient) TransferPenalty(signer *Signer, peerPubkey string, address ontcommon.Address) (ontcommon.Uint256, error) {
+	return this.invoke(signer, "transferPenalty", &governance.TransferPenaltyParam{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+	})
+}
+
+//GetGovernanceAdmin return the admin of governance methods, the operator of global params contract
+func (this *GovernanceClient) GetGovernanceAdmin() (ontcommon.Address, error) {
+	contractAddress := utils.ParamContractAddress
+	value, err := this.chain.GetStorage(contractAddress.ToHexString(), GovernanceAdminKey())
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, &StorageError{Contract: contractAddress, Item: "operator", Err: err}
+	}
+	admin, err := utils.DecodeAddress(ontcommon.NewZeroCopySource(value))
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, &StorageError{Contract: contractAddress, Item: "operator", Err: err}
+	}
+	return admin, nil
+}
+
+//GetVbftConfig return the vbft config in effect
+func (this *GovernanceClient) GetVbftConfig() (*governance.Configuration, error) {
+	config := new(governance.Configuration)
+	err := this.getItem("vbftConfig", []byte(governance.VBFT_CONFIG), config.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+//GetPreConfig return the vbft config taking effect in next round
+func (this *GovernanceClient) GetPreConfig() (*governance.Configuration, error) {
+	preConfig := new(governance.PreConfig)
+	err := this.getItem("preConfig", []byte(governance.PRE_CONFIG), preConfig.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return preConfig.Configuration, nil
+}
+
+//GetNextVbftConfig return the vbft config the next commitDpos elects by: the pre config if it was updated in the
+//current view, the config in effect otherwise
+func (this *GovernanceClient) GetNextVbftConfig() (*governance.Configuration, error) {
+	preConfig := new(governance.PreConfig)
+	err := this.getOptionalItem("preConfig", []byte(governance.PRE_CONFIG), preConfig.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	if preConfig.Configuration != nil {
+		view, err := this.GetView()
+		if err != nil {
+			return nil, err
+		}
+		if preConfig.SetView == view {
+			return preConfig.Configuration, nil
+		}
+	}
+	return this.GetVbftConfig()
+}
+
+//GetGlobalParam return the global params
+func (this *GovernanceClient) GetGlobalParam() (*governance.GlobalParam, error) {
+	globalParam := new(governance.GlobalParam)
+	err := this.getItem("globalParam", []byte(governance.GLOBAL_PARAM), globalParam.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return globalParam, nil
+}
+
+//GetGlobalParam2 return the global params 2, zero values if not set yet
+func (this *GovernanceClient) GetGlobalParam2() (*governance.GlobalParam2, error) {
+	globalParam2 := new(governance.GlobalParam2)
+	err := this.getOptionalItem("globalParam2", []byte(governance.GLOBAL_PARAM2), globalParam2.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return globalParam2, nil
+}
+
+//GetSplitCurve return the split curve
+func (this *GovernanceClient) GetSplitCurve() (*governance.SplitCurve, error) {
+	splitCurve := new(governance.SplitCurve)
+	err := this.getItem("splitCurve", []byte(governance.SPLIT_CURVE), splitCurve.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return splitCurve, nil
+}
+
+//GetGovernanceView return the current governance view
+func (this *GovernanceClient) GetGovernanceView() (*governance.GovernanceView, error) {
+	value, err := this.getStorage("governanceView", []byte(governance.GOVERNANCE_VIEW))
+	if err != nil {
+		return nil, err
+	}
+	governanceView := new(governance.GovernanceView)
+	err = governanceView.Deserialize(bytes.NewBuffer(value))
+	if err != nil {
+		return nil, &StorageError{Contract: this.contract, Item: "governanceView", Err: err}
+	}
+	return governanceView, nil
+}
+
+//GetView return the current view
+func (this *GovernanceClient) GetView() (uint32, error) {
+	governanceView, err := this.GetGovernanceView()
+	if err != nil {
+		return 0, err
+	}
+	return governanceView.View, nil
+}
+
+//GetPeerPoolMap return the peer pool of the current view
+func (this *GovernanceClient) GetPeerPoolMap() (*governance.PeerPoolMap, error) {
+	view, err := this.GetView()
+	if err != nil {
+		return nil, err
+	}
+	return this.GetPeerPoolMapOfView(view)
+}
+
+//GetPeerPoolMapOfView return the peer pool of view, ErrViewNotFound if it is not in storage. commitDpos deletes
+//the pool of the view before the previous one, so only the current and the previous view can be read
+func (this *GovernanceClient) GetPeerPoolMapOfView(view uint32) (*governance.PeerPoolMap, error) {
+	value, err := this.getStorage("peerPoolMap", PeerPoolMapKey(view))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrViewNotFound, view)
+	}
+	peerPoolMap := &governance.PeerPoolMap{
+		PeerPoolMap: make(map[string]*governance.PeerPoolItem),
+	}
+	err = peerPoolMap.Deserialization(ontcommon.NewZeroCopySource(value))
+	if err != nil {
+		return nil, &StorageError{Contract: this.contract, Item: "peerPoolMap", Err: err}
+	}
+	return peerPoolMap, nil
+}
+
+//GetPeerPoolItem return the peer pool item of peerPubkey in the current view, ErrPeerNotFound if not in pool
+func (this *GovernanceClient) GetPeerPoolItem(peerPubkey string) (*governance.PeerPoolItem, error) {
+	peerPoolMap, err := this.GetPeerPoolMap()
+	if err != nil {
+		return nil, err
+	}
+	peerPoolItem, ok := peerPoolMap.PeerPoolMap[peerPubkey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPeerNotFound, peerPubkey)
+	}
+	return peerPoolItem, nil
+}
+
+//GetAuthorizeInfo return what address authorized to peerPubkey, zero pos if it never authorized
+func (this *GovernanceClient) GetAuthorizeInfo(peerPubkey string, address ontcommon.Address) (*governance.AuthorizeInfo, error) {
+	key, err := AuthorizeInfoKey(peerPubkey, address)
+	if err != nil {
+		return nil, err
+	}
+	authorizeInfo := &governance.AuthorizeInfo{
+		PeerPubkey: peerPubkey,
+		Address:    address,
+	}
+	err = this.getOptionalItem("authorizeInfo", key, authorizeInfo.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return authorizeInfo, nil
+}
+
+//InBlackList return true if peerPubkey is in the black list
+func (this *GovernanceClient) InBlackList(peerPubkey string) (bool, error) {
+	key, err := BlackListKey(peerPubkey)
+	if err != nil {
+		return false, err
+	}
+	value, err := this.getStorage("blackList", key)
+	if err != nil {
+		return false, err
+	}
+	return len(value) != 0, nil
+}
+
+//GetTotalStake return the total stake of address
+func (this *GovernanceClient) GetTotalStake(address ontcommon.Address) (*governance.TotalStake, error) {
+	totalStake := new(governance.TotalStake)
+	err := this.getItem("totalStake", TotalStakeKey(address), totalStake.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return totalStake, nil
+}
+
+//GetPenaltyStake return the penalty stake of peerPubkey
+func (this *GovernanceClient) GetPenaltyStake(peerPubkey string) (*governance.PenaltyStake, error) {
+	key, err := PenaltyStakeKey(peerPubkey)
+	if err != nil {
+		return nil, err
+	}
+	penaltyStake := new(governance.PenaltyStake)
+	err = this.getItem("penaltyStake", key, penaltyStake.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return penaltyStake, nil
+}
+
+//GetAttributes return the attributes of peerPubkey, the defaults of the contract if not set yet: peer costs 100
+//and MaxAuthorize 0
+func (this *GovernanceClient) GetAttributes(peerPubkey string) (*governance.PeerAttributes, error) {
+	key, err := PeerAttributesKey(peerPubkey)
+	if err != nil {
+		return nil, err
+	}
+	peerAttributes := &governance.PeerAttributes{
+		PeerPubkey: peerPubkey,
+		T2PeerCost: 100,
+		T1PeerCost: 100,
+		TPeerCost:  100,
+	}
+	err = this.getOptionalItem("peerAttributes", key, peerAttributes.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return peerAttributes, nil
+}
+
+//GetSplitFeeAddress return the split fee of address not withdrawn yet
+func (this *GovernanceClient) GetSplitFeeAddress(address ontcommon.Address) (*governance.SplitFeeAddress, error) {
+	splitFeeAddress := new(governance.SplitFeeAddress)
+	err := this.getItem("splitFeeAddress", SplitFeeAddressKey(address), splitFeeAddress.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return splitFeeAddress, nil
+}
+
+//GetSplitFee return the total split fee not withdrawn yet, 0 before the first split
+func (this *GovernanceClient) GetSplitFee() (uint64, error) {
+	value, err := this.getStorage("splitFee", []byte(governance.SPLIT_FEE))
+	if err != nil {
+		return 0, err
+	}
+	if len(value) == 0 {
+		return 0, nil
+	}
+	splitFee, err := serialization.ReadUint64(bytes.NewBuffer(value))
+	if err != nil {
+		return 0, &StorageError{Contract: this.contract, Item: "splitFee", Err: err}
+	}
+	return splitFee, nil
+}
+
+//GetGasAddress return the address the dapp share of fees is split to, empty if not set
+func (this *GovernanceClient) GetGasAddress() (ontcommon.Address, error) {
+	gasAddress := new(governance.GasAddress)
+	err := this.getOptionalItem("gasAddress", []byte(governance.GAS_ADDRESS), gasAddress.Deserialization)
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, err
+	}
+	return gasAddress.Address, nil
+}
+
+//GetPromisePos return the promise pos of peerPubkey
+func (this *GovernanceClient) GetPromisePos(peerPubkey string) (*governance.PromisePos, error) {
+	key, err := PromisePosKey(peerPubkey)
+	if err != nil {
+		return nil, err
+	}
+	promisePos := new(governance.PromisePos)
+	err = this.getItem("promisePos", key, promisePos.Deserialization)
+	if err != nil {
+		return nil, err
+	}
+	return promisePos, nil
+}
+
+//getItem read the storage item of key and decode it by deserialize
+func (this *GovernanceClient) getItem(item string, key []byte, deserialize func(*ontcommon.ZeroCopySource) error) error {
+	value, err := this.getStorage(item, key)
+	if err != nil {
+		return err
+	}
+	err = deserialize(ontcommon.NewZeroCopySource(value))
+	if err != nil {
+		return &StorageError{Contract: this.contract, Item: item, Err: err}
+	}
+	return nil
+}
+
+//getOptionalItem is getItem, an empty storage item is not decoded
+func (this *GovernanceClient) getOptionalItem(item string, key []byte, deserialize func(*ontcommon.ZeroCopySource) error) error {
+	value, err := this.getStorage(item, key)
+	if err != nil {
+		return err
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	err = deserialize(ontcommon.NewZeroCopySource(value))
+	if err != nil {
+		return &StorageError{Contract: this.contract, Item: item, Err: err}
+	}
+	return nil
+}
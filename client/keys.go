@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/global_params"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//Storage keys of governance items, relative to the contract. Items not listed here are stored under their name,
+//such as []byte(governance.VBFT_CONFIG)
+
+//GovernanceAdminKey return the key of the governance admin in the global params contract
+func GovernanceAdminKey() []byte {
+	return []byte(global_params.OPERATOR)
+}
+
+//PeerPoolMapKey return the key of the peer pool of view
+func PeerPoolMapKey(view uint32) []byte {
+	return common.ConcatKey([]byte(governance.PEER_POOL), governance.GetUint32Bytes(view))
+}
+
+//AuthorizeInfoKey return the key of what address authorized to peerPubkey
+func AuthorizeInfoKey(peerPubkey string, address ontcommon.Address) ([]byte, error) {
+	return peerKey(string(governance.AUTHORIZE_INFO_POOL), peerPubkey, address[:])
+}
+
+//BlackListKey return the key of peerPubkey in the black list
+func BlackListKey(peerPubkey string) ([]byte, error) {
+	return peerKey(governance.BLACK_LIST, peerPubkey)
+}
+
+//PenaltyStakeKey return the key of the penalty stake of peerPubkey
+func PenaltyStakeKey(peerPubkey string) ([]byte, error) {
+	return peerKey(governance.PENALTY_STAKE, peerPubkey)
+}
+
+//PeerAttributesKey return the key of the attributes of peerPubkey
+func PeerAttributesKey(peerPubkey string) ([]byte, error) {
+	return peerKey(governance.PEER_ATTRIBUTES, peerPubkey)
+}
+
+//PromisePosKey return the key of the promise pos of peerPubkey
+func PromisePosKey(peerPubkey string) ([]byte, error) {
+	return peerKey(governance.PROMISE_POS, peerPubkey)
+}
+
+//TotalStakeKey return the key of the total stake of address
+func TotalStakeKey(address ontcommon.Address) []byte {
+	return common.ConcatKey([]byte(governance.TOTAL_STAKE), address[:])
+}
+
+//SplitFeeAddressKey return the key of the split fee of address
+func SplitFeeAddressKey(address ontcommon.Address) []byte {
+	return common.ConcatKey([]byte(governance.SPLIT_FEE_ADDRESS), address[:])
+}
+
+//peerKey return the key of item of peerPubkey, followed by suffix
+func peerKey(item string, peerPubkey string, suffix ...[]byte) ([]byte, error) {
+	peerPubkeyPrefix, err := decodePeerPubkey(peerPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return common.ConcatKey(append([][]byte{[]byte(item), peerPubkeyPrefix}, suffix...)...), nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//ONT_ID_PREFIX is the prefix of ONT IDs derived from an address
+const ONT_ID_PREFIX = "did:ont:"
+
+//OntId return the ONT ID of address
+func OntId(address ontcommon.Address) string {
+	return ONT_ID_PREFIX + address.ToBase58()
+}
+
+//RegIDWithPublicKeyParam is the param of regIDWithPublicKey
+type RegIDWithPublicKeyParam struct {
+	OntID  []byte
+	Pubkey []byte
+}
+
+//OntIdClient invoke the ONT ID contract
+type OntIdClient struct {
+	nativeClient
+}
+
+//NewOntIdClient return a client of the ONT ID contract on chain, sending transactions by options
+func NewOntIdClient(chain common.Chain, options *Options) *OntIdClient {
+	return &OntIdClient{
+		nativeClient: newNativeClient(chain, utils.OntIDContractAddress, options),
+	}
+}
+
+//RegIDWithPublicKey register the ONT ID of user with its public key
+func (this *OntIdClient) RegIDWithPublicKey(user *sdk.Account) (ontcommon.Uint256, error) {
+	return this.invoke(NewAccountSigner(user), "regIDWithPublicKey", RegIDWithPublicKeyParam{
+		OntID:  []byte(OntId(user.Address)),
+		Pubkey: keypair.SerializePublicKey(user.PublicKey),
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//Signer sign transactions, either by accounts one by one, the first one pays, or for the multi-sign address
+//of PubKeys
+type Signer struct {
+	//Accounts sign the transaction. For a multi-sign address they are members of PubKeys, the transaction is
+	//left unsigned if empty
+	Accounts []*sdk.Account
+	//PubKeys of the multi-sign address, empty if accounts sign by themselves
+	PubKeys []keypair.PublicKey
+	//M of the multi-sign address, default is common.MultiSignM(len(PubKeys))
+	M uint16
+}
+
+//NewAccountSigner return a signer of accounts, the first one pays
+func NewAccountSigner(accounts ...*sdk.Account) *Signer {
+	return &Signer{Accounts: accounts}
+}
+
+//NewMultiSigner return a signer of the m-of-n multi-sign address of pubKeys, accounts are the members who sign
+func NewMultiSigner(pubKeys []keypair.PublicKey, m uint16, accounts []*sdk.Account) *Signer {
+	return &Signer{
+		Accounts: accounts,
+		PubKeys:  pubKeys,
+		M:        m,
+	}
+}
+
+//IsMultiSign return true if the signer signs for a multi-sign address
+func (this *Signer) IsMultiSign() bool {
+	return len(this.PubKeys) > 0
+}
+
+//Address return the multi-sign address, or the address of the first account
+func (this *Signer) Address() (ontcommon.Address, error) {
+	if this.IsMultiSign() {
+		_, address, err := common.MultiSignAddress(this.PubKeys, this.M, "")
+		return address, err
+	}
+	if len(this.Accounts) == 0 {
+		return ontcommon.ADDRESS_EMPTY, ErrNoSigner
+	}
+	return this.Accounts[0].Address, nil
+}
+
+//sign set who sign nativeTx
+func (this *Signer) sign(nativeTx *common.NativeTx) error {
+	if this == nil {
+		return ErrNoSigner
+	}
+	if this.IsMultiSign() {
+		nativeTx.PubKeys = this.PubKeys
+		nativeTx.M = this.M
+		nativeTx.MultiSigners = this.Accounts
+		return nil
+	}
+	if len(this.Accounts) == 0 {
+		return ErrNoSigner
+	}
+	nativeTx.Signers = this.Accounts
+	return nil
+}
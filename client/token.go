@@ -0,0 +1,101 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//NativeTokenClient invoke the ONT or ONG contract
+type NativeTokenClient struct {
+	nativeClient
+}
+
+//NewOntClient return a client of the ONT contract on chain, sending transactions by options
+func NewOntClient(chain common.Chain, options *Options) *NativeTokenClient {
+	return &NativeTokenClient{
+		nativeClient: newNativeClient(chain, utils.OntContractAddress, options),
+	}
+}
+
+//NewOngClient return a client of the ONG contract on chain, sending transactions by options
+func NewOngClient(chain common.Chain, options *Options) *NativeTokenClient {
+	return &NativeTokenClient{
+		nativeClient: newNativeClient(chain, utils.OngContractAddress, options),
+	}
+}
+
+//Transfer transfer amount from the signer address to address
+func (this *NativeTokenClient) Transfer(signer *Signer, to ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
+	from, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "transfer", ont.Transfers{
+		States: []ont.State{{From: from, To: to, Value: amount}},
+	})
+}
+
+//MultiTransfer transfer amount[i] from from[i] to to[i] in one transaction signed by every sender
+func (this *NativeTokenClient) MultiTransfer(from []*sdk.Account, to []ontcommon.Address, amount []uint64) (ontcommon.Uint256, error) {
+	if len(from) != len(to) || len(from) != len(amount) {
+		return ontcommon.UINT256_EMPTY, ErrLengthMismatch
+	}
+	transfers := ont.Transfers{}
+	for i := range from {
+		transfers.States = append(transfers.States, ont.State{
+			From:  from[i].Address,
+			To:    to[i],
+			Value: amount[i],
+		})
+	}
+	return this.invoke(NewAccountSigner(from...), "transfer", transfers)
+}
+
+//TransferFrom transfer amount approved by from to the signer address, to address. ONG unbound to ONT
+//holders is transferred from the ONT contract address
+func (this *NativeTokenClient) TransferFrom(signer *Signer, from ontcommon.Address, to ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
+	sender, err := signer.Address()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return this.invoke(signer, "transferFrom", &ont.TransferFrom{
+		Sender: sender,
+		From:   from,
+		To:     to,
+		Value:  amount,
+	})
+}
+
+//BalanceOf return the balance of address
+func (this *NativeTokenClient) BalanceOf(address ontcommon.Address) (uint64, error) {
+	result, err := this.preExec(ont.BALANCEOF_NAME, address[:])
+	if err != nil {
+		return 0, err
+	}
+	balance, err := result.ToInteger()
+	if err != nil {
+		return 0, &TxError{Contract: this.contract, Method: ont.BALANCEOF_NAME, Err: err}
+	}
+	return balance.Uint64(), nil
+}
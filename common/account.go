@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	scommon "github.com/ontio/ontology/common"
+)
+
+//ACCOUNT_SELECTOR_SEP separates the wallet path and the account selector of an account path
+const ACCOUNT_SELECTOR_SEP = "#"
+
+//ParseAccountPath split an account path "<wallet path>#<address or label>" into wallet path and selector.
+//Selector is empty for the default account, and wallet path is config.Wallet if omitted, as in "#peer3"
+func ParseAccountPath(accountPath string) (string, string) {
+	path, selector := accountPath, ""
+	if index := strings.LastIndex(accountPath, ACCOUNT_SELECTOR_SEP); index >= 0 {
+		path, selector = accountPath[:index], accountPath[index+1:]
+	}
+	if path == "" {
+		path = config.DefConfig.Wallet
+	}
+	return path, selector
+}
+
+//openAccountData return the wallet of account path and the data of the selected account, no password needed
+func openAccountData(ontSdk *sdk.OntologySdk, accountPath string) (*sdk.Wallet, *sdk.AccountData, error) {
+	path, selector := ParseAccountPath(accountPath)
+	wallet, err := ontSdk.OpenWallet(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open wallet %s error:%s", path, err)
+	}
+	var accData *sdk.AccountData
+	if selector == "" {
+		accData, err = wallet.GetDefaultAccountData()
+		if err != nil {
+			return nil, nil, fmt.Errorf("GetDefaultAccountData of wallet %s error:%s", path, err)
+		}
+		return wallet, accData, nil
+	}
+	if _, addrErr := scommon.AddressFromBase58(selector); addrErr == nil {
+		accData, err = wallet.GetAccountDataByAddress(selector)
+	} else {
+		accData, err = wallet.GetAccountDataByLabel(selector)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("account %s of wallet %s error:%s", selector, path, err)
+	}
+	return wallet, accData, nil
+}
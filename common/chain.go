@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+//Chain is the access of the tool to an ontology chain: storage, blocks, transactions and their events.
+//*sdk.OntologySdk connected to a node implements it, package fakechain keeps a chain in memory
+type Chain interface {
+	GetCurrentBlockHeight() (uint32, error)
+	GetBlockByHeight(height uint32) (*types.Block, error)
+	GetBlockHash(height uint32) (scommon.Uint256, error)
+	GetStorage(contractAddress string, key []byte) ([]byte, error)
+	PreExecTransaction(tx *types.MutableTransaction) (*sdkcom.PreExecResult, error)
+	SendTransaction(tx *types.MutableTransaction) (scommon.Uint256, error)
+	WaitForGenerateBlock(timeout time.Duration, blockCount ...uint32) (bool, error)
+	GetSmartContractEvent(txHash string) (*sdkcom.SmartContactEvent, error)
+	GetBlockHeightByTxHash(txHash string) (uint32, error)
+}
+
+var _ Chain = (*sdk.OntologySdk)(nil)
+
+//txBuilder build and sign transactions, which needs no connection to a node
+var txBuilder = sdk.NewOntologySdk()
@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log4 "github.com/alecthomas/log4go"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-go-sdk/client"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
+)
+
+//watcher deliver events pushed by websocket, nil if transactions are confirmed by polling
+var watcher *eventWatcher
+
+//rpcTransport carry json rpc requests to nodes if set, such as the recorder of a fixture
+var rpcTransport http.RoundTripper
+
+//RPC_TIMEOUT is the timeout of a json rpc request sent by rpcTransport
+const RPC_TIMEOUT = 300 * time.Second
+
+//SetRpcTransport set the http transport of json rpc requests sent afterwards, nil is the default transport
+func SetRpcTransport(transport http.RoundTripper) {
+	rpcTransport = transport
+}
+
+//newRpcClient return a json rpc client of ontSdk to address, which sends by rpcTransport if set
+func newRpcClient(ontSdk *sdk.OntologySdk, address string) *client.RpcClient {
+	rpcClient := ontSdk.NewRpcClient().SetAddress(address)
+	if rpcTransport != nil {
+		rpcClient.SetHttpClient(&http.Client{Transport: rpcTransport, Timeout: RPC_TIMEOUT})
+	}
+	return rpcClient
+}
+
+//NewOntologySdk return a sdk connected to ontology by the configured transport. If ConfirmByWebSocket is set,
+//smart contract events are subscribed on WebSocketAddress to confirm transactions
+func NewOntologySdk() (*sdk.OntologySdk, error) {
+	ontSdk := sdk.NewOntologySdk()
+	var wsClient *client.WSClient
+	switch config.DefConfig.Transport {
+	case config.TRANSPORT_RPC, config.TRANSPORT_REST, "":
+		addresses := config.DefConfig.Endpoints
+		if len(addresses) == 0 {
+			name, address := "JsonRpcAddress", config.DefConfig.JsonRpcAddress
+			if config.DefConfig.Transport == config.TRANSPORT_REST {
+				name, address = "RestfulAddress", config.DefConfig.RestfulAddress
+			}
+			if address == "" {
+				return nil, fmt.Errorf("%s is empty", name)
+			}
+			addresses = []string{address}
+		}
+		if config.DefConfig.Transport == config.TRANSPORT_REST {
+			ontSdk.NewRestClient().SetAddress(addresses[0])
+		} else {
+			newRpcClient(ontSdk, addresses[0])
+		}
+		if len(addresses) > 1 {
+			endpoints = newEndpointPool(ontSdk, config.DefConfig.Transport, addresses)
+			err := endpoints.check()
+			if err != nil {
+				return nil, err
+			}
+			log4.Info("Endpoints: %s, reads go to %s", endpoints.describe(), endpoints.currentAddress())
+		}
+	case config.TRANSPORT_WS:
+		if len(config.DefConfig.Endpoints) > 0 {
+			return nil, fmt.Errorf("Endpoints is not supported by transport %s", config.TRANSPORT_WS)
+		}
+		var err error
+		wsClient, err = connectWebSocket(ontSdk)
+		if err != nil {
+			return nil, err
+		}
+		ontSdk.SetDefaultClient(wsClient)
+	default:
+		return nil, fmt.Errorf("unknown transport %s, should be %s, %s or %s", config.DefConfig.Transport,
+			config.TRANSPORT_RPC, config.TRANSPORT_REST, config.TRANSPORT_WS)
+	}
+	if config.DefConfig.GenesisHash != "" {
+		err := checkChainIdentity(ontSdk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.DefConfig.ConfirmByWebSocket {
+		if wsClient == nil {
+			var err error
+			wsClient, err = connectWebSocket(ontSdk)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err := wsClient.SubscribeEvent()
+		if err != nil {
+			return nil, fmt.Errorf("SubscribeEvent error:%s", err)
+		}
+		watcher = newEventWatcher()
+		go watcher.run(wsClient.GetActionCh())
+		log4.Info("Confirm transactions by events of %s", config.DefConfig.WebSocketAddress)
+	}
+	return ontSdk, nil
+}
+
+//checkChainIdentity return error if the genesis block hash of the node, or of any reachable endpoint, is not
+//GenesisHash of the selected network
+func checkChainIdentity(ontSdk *sdk.OntologySdk) error {
+	nodes := map[string]*sdk.OntologySdk{"node": ontSdk}
+	if endpoints != nil {
+		nodes = make(map[string]*sdk.OntologySdk)
+		for _, endpoint := range endpoints.endpoints {
+			if endpoint.Err == nil {
+				nodes[endpoint.Address] = endpoint.sdk
+			}
+		}
+	}
+	for name, nodeSdk := range nodes {
+		genesisHash, err := nodeSdk.GetBlockHash(0)
+		if err != nil {
+			return fmt.Errorf("GetBlockHash 0 of %s error:%s", name, err)
+		}
+		if genesisHash.ToHexString() != config.DefConfig.GenesisHash {
+			return fmt.Errorf("%s is on chain with genesis hash %s, network %s expects %s", name,
+				genesisHash.ToHexString(), config.DefConfig.NetworkName(), config.DefConfig.GenesisHash)
+		}
+	}
+	log4.Info("Connected to network %s, genesis hash %s", config.DefConfig.NetworkName(), config.DefConfig.GenesisHash)
+	return nil
+}
+
+func connectWebSocket(ontSdk *sdk.OntologySdk) (*client.WSClient, error) {
+	if config.DefConfig.WebSocketAddress == "" {
+		return nil, fmt.Errorf("WebSocketAddress is empty")
+	}
+	wsClient := ontSdk.NewWebSocketClient()
+	err := wsClient.Connect(config.DefConfig.WebSocketAddress)
+	if err != nil {
+		return nil, fmt.Errorf("connect %s error:%s", config.DefConfig.WebSocketAddress, err)
+	}
+	return wsClient, nil
+}
+
+//eventWatcher keep smart contract events pushed by websocket for transactions sent in this run
+type eventWatcher struct {
+	lock   sync.Mutex
+	events map[string]*sdkcom.SmartContactEvent
+}
+
+func newEventWatcher() *eventWatcher {
+	return &eventWatcher{
+		events: make(map[string]*sdkcom.SmartContactEvent),
+	}
+}
+
+func (this *eventWatcher) run(actionCh chan *client.WSAction) {
+	for action := range actionCh {
+		if action.Action != sdkcom.WS_SUBSCRIBE_ACTION_EVENT_NOTIFY {
+			continue
+		}
+		event, ok := action.Result.(*sdkcom.SmartContactEvent)
+		if !ok || sentTxs.get(event.TxHash) == nil {
+			continue
+		}
+		this.lock.Lock()
+		this.events[event.TxHash] = event
+		this.lock.Unlock()
+	}
+}
+
+//get return the pushed event of txHash, nil if not pushed yet
+func (this *eventWatcher) get(txHash string) *sdkcom.SmartContactEvent {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.events[txHash]
+}
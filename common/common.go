@@ -19,71 +19,113 @@
 package common
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 
-	log4 "github.com/alecthomas/log4go"
 	"github.com/ontio/ontology-crypto/keypair"
 	sdk "github.com/ontio/ontology-go-sdk"
 	scommon "github.com/ontio/ontology/common"
-	"github.com/ontio/ontology/common/password"
 	"github.com/ontio/ontology/consensus/vbft"
 	"github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/types"
 )
 
-func GetAccountByPassword(sdk *sdk.OntologySdk, path string) (*sdk.Account, bool) {
-	wallet, err := sdk.OpenWallet(path)
-	if err != nil {
-		log4.Error("open wallet error:", err)
-		return nil, false
-	}
-	pwd, err := password.GetPassword()
-	if err != nil {
-		log4.Error("getPassword error:", err)
-		return nil, false
-	}
-	user, err := wallet.GetDefaultAccount(pwd)
-	if err != nil {
-		log4.Error("getDefaultAccount error:", err)
-		return nil, false
-	}
-	return user, true
-}
-
+//InvokeNativeContractWithMultiSign build a native invoke transaction signed by singers for the m-of-n
+//multi-sign address of pubKeys, then send it. m is MultiSignM(n) if 0
 func InvokeNativeContractWithMultiSign(
-	sdk *sdk.OntologySdk,
+	chain Chain,
 	gasPrice,
 	gasLimit uint64,
 	pubKeys []keypair.PublicKey,
+	m uint16,
 	singers []*sdk.Account,
 	cversion byte,
 	contractAddress scommon.Address,
 	method string,
 	params []interface{},
 ) (scommon.Uint256, error) {
-	tx, err := sdk.Native.NewNativeInvokeTransaction(gasPrice, gasLimit, cversion, contractAddress, method, params)
+	return SendNativeTx(chain, &NativeTx{
+		GasPrice:     gasPrice,
+		GasLimit:     gasLimit,
+		Version:      cversion,
+		Contract:     contractAddress,
+		Method:       method,
+		Params:       params,
+		PubKeys:      pubKeys,
+		M:            m,
+		MultiSigners: singers,
+	})
+}
+
+//InvokeNativeContract build a native invoke transaction signed by payer and singer, then send it
+func InvokeNativeContract(
+	chain Chain,
+	gasPrice,
+	gasLimit uint64,
+	payer,
+	singer *sdk.Account,
+	cversion byte,
+	contractAddress scommon.Address,
+	method string,
+	params []interface{},
+) (scommon.Uint256, error) {
+	return SendNativeTx(chain, &NativeTx{
+		GasPrice: gasPrice,
+		GasLimit: gasLimit,
+		Version:  cversion,
+		Contract: contractAddress,
+		Method:   method,
+		Params:   params,
+		Payer:    payer,
+		Signers:  []*sdk.Account{singer},
+	})
+}
+
+//WaitForBlocks wait count new blocks, return error on timeout or ctx done. Nothing to wait in dry-run
+//and export mode
+func WaitForBlocks(ctx context.Context, chain Chain, count uint32, timeout time.Duration) error {
+	if TxNotSent() {
+		return nil
+	}
+	height, err := chain.GetCurrentBlockHeight()
 	if err != nil {
-		return scommon.UINT256_EMPTY, err
+		return fmt.Errorf("GetCurrentBlockHeight error:%s", err)
 	}
-	for _, singer := range singers {
-		err = sdk.MultiSignToTransaction(tx, uint16((5*len(pubKeys)+6)/7), pubKeys, singer)
-		if err != nil {
-			return scommon.UINT256_EMPTY, err
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("wait %d blocks timeout after %s", count, timeout)
+		case <-ticker.C:
+			curHeight, err := chain.GetCurrentBlockHeight()
+			if err != nil {
+				continue
+			}
+			if curHeight-height >= count {
+				return nil
+			}
 		}
 	}
-	return sdk.SendTransaction(tx)
 }
 
-func WaitForBlock(sdk *sdk.OntologySdk) bool {
-	_, err := sdk.WaitForGenerateBlock(30*time.Second, 1)
-	if err != nil {
-		log4.Error("WaitForGenerateBlock error:", err)
-		return false
+//Sleep sleep d, return error if ctx done before
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	return true
 }
 
 func ConcatKey(args ...[]byte) []byte {
@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+//WS_POLL_TICKS is the seconds between queries of a transaction when its event is expected from websocket
+const WS_POLL_TICKS = 10
+
+//sentTxs keep transactions sent in this run and their pre-execution results by hash, so a failed one
+//can be replayed to find the reason
+var sentTxs = &sentTxCache{
+	txs:      make(map[string]*types.MutableTransaction),
+	preExecs: make(map[string]*PreExecInfo),
+}
+
+type sentTxCache struct {
+	lock     sync.RWMutex
+	txs      map[string]*types.MutableTransaction
+	preExecs map[string]*PreExecInfo
+}
+
+func (this *sentTxCache) add(tx *types.MutableTransaction) {
+	txHash := tx.Hash()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.txs[txHash.ToHexString()] = tx
+}
+
+func (this *sentTxCache) get(txHash string) *types.MutableTransaction {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.txs[txHash]
+}
+
+func (this *sentTxCache) addPreExec(info *PreExecInfo) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.preExecs[info.TxHash] = info
+}
+
+//GetPreExec return the pre-execution result of a transaction built in this run, nil if not pre-executed
+func GetPreExec(txHash string) *PreExecInfo {
+	sentTxs.lock.RLock()
+	defer sentTxs.lock.RUnlock()
+	return sentTxs.preExecs[txHash]
+}
+
+//TxConfirmation is the execution result of a transaction on chain
+type TxConfirmation struct {
+	TxHash      string
+	Height      uint32
+	State       byte
+	GasConsumed uint64
+	//Events are the decoded notify events, "<contract name>: <states json>"
+	Events []string
+}
+
+//ConfirmTx wait until tx is included and config.ConfirmDepth blocks are on top of it, in config.ConfirmTimeout
+//seconds. Return error if tx is not confirmed in time or its execution State is 0
+func ConfirmTx(ctx context.Context, chain Chain, txHash string) (*TxConfirmation, error) {
+	timeout := time.Duration(config.DefConfig.ConfirmTimeout) * time.Second
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var confirmation *TxConfirmation
+	var lastErr error
+	for tick := 0; ; tick++ {
+		//with pushed events, node is only queried every WS_POLL_TICKS seconds in case an event is missed
+		if confirmation == nil && (watcher == nil || watcher.get(txHash) != nil || tick%WS_POLL_TICKS == 0) {
+			confirmation, lastErr = getTxConfirmation(chain, txHash)
+		}
+		if confirmation != nil {
+			curHeight, err := chain.GetCurrentBlockHeight()
+			if err == nil && curHeight >= confirmation.Height+config.DefConfig.ConfirmDepth {
+				break
+			}
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			return confirmation, ctx.Err()
+		case <-deadline.C:
+			if confirmation == nil {
+				return nil, fmt.Errorf("tx %s not included in %s, last error:%v", txHash, timeout, lastErr)
+			}
+			return confirmation, fmt.Errorf("tx %s included at height %d but not %d blocks deep in %s", txHash, confirmation.Height, config.DefConfig.ConfirmDepth, timeout)
+		case <-ticker.C:
+		}
+	}
+	if confirmation.State == 0 {
+		return confirmation, fmt.Errorf("tx %s failed at height %d, gas consumed %d: %s", txHash, confirmation.Height, confirmation.GasConsumed, failedReason(chain, txHash, confirmation))
+	}
+	return confirmation, nil
+}
+
+//getTxConfirmation return nil if tx is not included yet
+func getTxConfirmation(chain Chain, txHash string) (*TxConfirmation, error) {
+	var event *sdkcom.SmartContactEvent
+	if watcher != nil {
+		event = watcher.get(txHash)
+	}
+	if event == nil {
+		var err error
+		event, err = chain.GetSmartContractEvent(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("GetSmartContractEvent error:%s", err)
+		}
+	}
+	if event == nil {
+		return nil, nil
+	}
+	height, err := chain.GetBlockHeightByTxHash(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlockHeightByTxHash error:%s", err)
+	}
+	return &TxConfirmation{
+		TxHash:      txHash,
+		Height:      height,
+		State:       event.State,
+		GasConsumed: event.GasConsumed,
+		Events:      decodeNotify(event.Notify),
+	}, nil
+}
+
+func decodeNotify(notify []*sdkcom.NotifyEventInfo) []string {
+	events := make([]string, 0, len(notify))
+	for _, info := range notify {
+		name := info.ContractAddress
+		address, err := scommon.AddressFromHexString(info.ContractAddress)
+		if err == nil && ContractName(address) != "unknown" {
+			name = ContractName(address)
+		}
+		states, err := json.Marshal(info.States)
+		if err != nil {
+			states = []byte(fmt.Sprintf("%v", info.States))
+		}
+		events = append(events, fmt.Sprintf("%s: %s", name, states))
+	}
+	return events
+}
+
+//failedReason explain why a tx failed. The chain does not record the error of a failed tx, so it is
+//replayed by pre-execution against the current state, which gives the contract error in most cases
+func failedReason(chain Chain, txHash string, confirmation *TxConfirmation) string {
+	tx := sentTxs.get(txHash)
+	if tx == nil {
+		return "execution state 0, no error recorded on chain"
+	}
+	if tx.GasPrice > 0 && confirmation.GasConsumed >= tx.GasLimit*tx.GasPrice {
+		return fmt.Sprintf("out of gas, gas limit %d", tx.GasLimit)
+	}
+	_, err := chain.PreExecTransaction(tx)
+	if err != nil {
+		return fmt.Sprintf("contract error:%s", err)
+	}
+	return "execution state 0, replay by pre-execution succeeded, the chain state may have changed"
+}
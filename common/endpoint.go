@@ -0,0 +1,188 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log4 "github.com/alecthomas/log4go"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-go-sdk/client"
+	"github.com/ontio/ontology-tool/config"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+//endpoints is the pool of node addresses, nil if only one address is configured
+var endpoints *endpointPool
+
+//Endpoint is a node address and its health
+type Endpoint struct {
+	Address string
+	Height  uint32
+	Latency time.Duration
+	Err     error
+	Healthy bool
+	sdk     *sdk.OntologySdk
+	client  client.OntologyClient
+}
+
+//endpointPool send reads of ontSdk to the first healthy endpoint, and broadcast to healthy endpoints in order
+type endpointPool struct {
+	lock      sync.Mutex
+	ontSdk    *sdk.OntologySdk
+	endpoints []*Endpoint
+	current   *Endpoint
+}
+
+func newEndpointPool(ontSdk *sdk.OntologySdk, transport string, addresses []string) *endpointPool {
+	pool := &endpointPool{ontSdk: ontSdk}
+	for _, address := range addresses {
+		endpointSdk := sdk.NewOntologySdk()
+		endpoint := &Endpoint{Address: address, sdk: endpointSdk}
+		if transport == config.TRANSPORT_REST {
+			endpoint.client = endpointSdk.NewRestClient().SetAddress(address)
+		} else {
+			endpoint.client = newRpcClient(endpointSdk, address)
+		}
+		pool.endpoints = append(pool.endpoints, endpoint)
+	}
+	return pool
+}
+
+//check health-check all endpoints by block height and latency, and send reads to the first healthy one
+func (this *endpointPool) check() error {
+	var wg sync.WaitGroup
+	for _, endpoint := range this.endpoints {
+		wg.Add(1)
+		go func(endpoint *Endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			endpoint.Height, endpoint.Err = endpoint.sdk.GetCurrentBlockHeight()
+			endpoint.Latency = time.Since(start)
+		}(endpoint)
+	}
+	wg.Wait()
+
+	var maxHeight, minHeight uint32
+	first := true
+	for _, endpoint := range this.endpoints {
+		if endpoint.Err != nil {
+			continue
+		}
+		if first || endpoint.Height > maxHeight {
+			maxHeight = endpoint.Height
+		}
+		if first || endpoint.Height < minHeight {
+			minHeight = endpoint.Height
+		}
+		first = false
+	}
+	if first {
+		return fmt.Errorf("no healthy endpoint: %s", this.describe())
+	}
+	if maxHeight-minHeight > config.DefConfig.MaxHeightDiff {
+		log4.Warn("Endpoints disagree on current height by %d blocks: %s", maxHeight-minHeight, this.describe())
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var healthy *Endpoint
+	for _, endpoint := range this.endpoints {
+		endpoint.Healthy = endpoint.Err == nil && maxHeight-endpoint.Height <= config.DefConfig.MaxHeightDiff
+		if endpoint.Healthy && healthy == nil {
+			healthy = endpoint
+		}
+	}
+	if healthy != this.current {
+		if this.current != nil {
+			log4.Warn("Endpoint %s is not healthy, switch to %s", this.current.Address, healthy.Address)
+		}
+		this.current = healthy
+		this.ontSdk.SetDefaultClient(healthy.client)
+	}
+	return nil
+}
+
+//describe return "address height latency" of every endpoint
+func (this *endpointPool) describe() string {
+	items := make([]string, 0, len(this.endpoints))
+	for _, endpoint := range this.endpoints {
+		if endpoint.Err != nil {
+			items = append(items, fmt.Sprintf("%s error:%s", endpoint.Address, endpoint.Err))
+			continue
+		}
+		items = append(items, fmt.Sprintf("%s height:%d latency:%s", endpoint.Address, endpoint.Height, endpoint.Latency.Round(time.Millisecond)))
+	}
+	return strings.Join(items, ", ")
+}
+
+//currentAddress return address of the endpoint reads are sent to
+func (this *endpointPool) currentAddress() string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.current.Address
+}
+
+//send broadcast tx to the current endpoint, and to the next healthy endpoints in order if it can not be
+//reached. A transaction rejected by a node is not retried
+func (this *endpointPool) send(tx *types.MutableTransaction) (scommon.Uint256, error) {
+	this.lock.Lock()
+	candidates := []*Endpoint{this.current}
+	for _, endpoint := range this.endpoints {
+		if endpoint.Healthy && endpoint != this.current {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	this.lock.Unlock()
+	var lastErr error
+	for _, endpoint := range candidates {
+		txHash, err := endpoint.sdk.SendTransaction(tx)
+		if err == nil {
+			return txHash, nil
+		}
+		if isNodeRejection(err) {
+			return scommon.UINT256_EMPTY, err
+		}
+		log4.Warn("Send tx to %s error:%s", endpoint.Address, err)
+		lastErr = err
+	}
+	return scommon.UINT256_EMPTY, fmt.Errorf("send tx to %d endpoints failed, last error:%s", len(candidates), lastErr)
+}
+
+//isNodeRejection return true if err is returned by a node, rather than failing to reach it
+func isNodeRejection(err error) bool {
+	return strings.Contains(err.Error(), "error code:")
+}
+
+//broadcastTransaction send tx to ontology. With several endpoints, heights are checked first and the
+//broadcast fails over to the next healthy endpoint
+func broadcastTransaction(chain Chain, tx *types.MutableTransaction) (scommon.Uint256, error) {
+	if endpoints == nil {
+		return chain.SendTransaction(tx)
+	}
+	err := endpoints.check()
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	return endpoints.send(tx)
+}
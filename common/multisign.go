@@ -0,0 +1,308 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	log4 "github.com/alecthomas/log4go"
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/signature"
+	"github.com/ontio/ontology/core/types"
+)
+
+//exportCount is the number of transactions exported in this run
+var exportCount = 0
+
+//MultiSignTx is the content of a multi-sign transaction file, passed from member to member to collect signatures
+type MultiSignTx struct {
+	TxHash   string
+	Contract string
+	Method   string
+	//Payer and sender of tx, the multi-sign address of PubKeys
+	Payer   string
+	M       uint16
+	PubKeys []string
+	//RawTx is hex of the serialized transaction with signatures collected so far
+	RawTx string
+}
+
+//MultiSignStatus is the signature status of a multi-sign transaction
+type MultiSignStatus struct {
+	M        uint16
+	N        int
+	Signed   []string
+	Unsigned []string
+}
+
+//Ready return true if enough members have signed
+func (this *MultiSignStatus) Ready() bool {
+	return len(this.Signed) >= int(this.M)
+}
+
+//MultiSignM return the threshold of n members, (5n+6)/7
+func MultiSignM(n int) uint16 {
+	return uint16((5*n + 6) / 7)
+}
+
+//MultiSignAddress return m and the address of the m-of-n multi-sign pubKeys, m is MultiSignM(n) if 0. Return
+//error if m is not in 1..n, or if expected is given and is not the derived address. A single public key is its
+//own address
+func MultiSignAddress(pubKeys []keypair.PublicKey, m uint16, expected string) (uint16, scommon.Address, error) {
+	if m == 0 {
+		m = MultiSignM(len(pubKeys))
+	}
+	if m < 1 || int(m) > len(pubKeys) {
+		return 0, scommon.ADDRESS_EMPTY, fmt.Errorf("invalid M %d of %d public keys", m, len(pubKeys))
+	}
+	if len(pubKeys) == 1 {
+		address := types.AddressFromPubKey(pubKeys[0])
+		if expected != "" && address.ToBase58() != expected {
+			return 0, scommon.ADDRESS_EMPTY, fmt.Errorf("address of public key is %s, not expected address %s", address.ToBase58(), expected)
+		}
+		return m, address, nil
+	}
+	address, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
+	if err != nil {
+		return 0, scommon.ADDRESS_EMPTY, fmt.Errorf("AddressFromMultiPubKeys error:%s", err)
+	}
+	if expected != "" && address.ToBase58() != expected {
+		return 0, scommon.ADDRESS_EMPTY, fmt.Errorf("%d-of-%d multi-sign address is %s, not expected address %s", m, len(pubKeys), address.ToBase58(), expected)
+	}
+	return m, address, nil
+}
+
+//TxNotSent return true if transactions are printed or exported instead of sent
+func TxNotSent() bool {
+	return config.DefConfig.DryRun || config.DefConfig.Export != ""
+}
+
+//GetPubKeyByWallet return public key of the account of account path "<wallet path>[#<address or label>]",
+//no password needed
+func GetPubKeyByWallet(ontSdk *sdk.OntologySdk, accountPath string) (keypair.PublicKey, error) {
+	_, accData, err := openAccountData(ontSdk, accountPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(accData.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString %s error:%s", accData.PubKey, err)
+	}
+	pubKey, err := keypair.DeserializePublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("keypair.DeserializePublicKey %s error:%s", accData.PubKey, err)
+	}
+	return pubKey, nil
+}
+
+//GetAddressByWallet return address of the account of account path, no password needed
+func GetAddressByWallet(ontSdk *sdk.OntologySdk, accountPath string) (scommon.Address, error) {
+	pubKey, err := GetPubKeyByWallet(ontSdk, accountPath)
+	if err != nil {
+		return scommon.ADDRESS_EMPTY, err
+	}
+	return types.AddressFromPubKey(pubKey), nil
+}
+
+//NewMultiSignTx create the file content of tx, which must be multi-signed by pubKeys
+func NewMultiSignTx(tx *types.MutableTransaction, pubKeys []keypair.PublicKey, nativeTx *NativeTx) (*MultiSignTx, error) {
+	multiSignTx := &MultiSignTx{
+		M:       MultiSignM(len(pubKeys)),
+		PubKeys: make([]string, 0, len(pubKeys)),
+	}
+	if nativeTx != nil {
+		multiSignTx.M = nativeTx.MultiSignM()
+	}
+	for _, pubKey := range pubKeys {
+		multiSignTx.PubKeys = append(multiSignTx.PubKeys, hex.EncodeToString(keypair.SerializePublicKey(pubKey)))
+	}
+	if nativeTx != nil {
+		multiSignTx.Contract = fmt.Sprintf("%s (%s)", nativeTx.Contract.ToHexString(), ContractName(nativeTx.Contract))
+		multiSignTx.Method = nativeTx.Method
+	}
+	err := multiSignTx.SetTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return multiSignTx, nil
+}
+
+//LoadMultiSignTx read a multi-sign transaction file
+func LoadMultiSignTx(path string) (*MultiSignTx, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile %s error:%s", path, err)
+	}
+	multiSignTx := &MultiSignTx{}
+	err = json.Unmarshal(data, multiSignTx)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal %s error:%s", path, err)
+	}
+	return multiSignTx, nil
+}
+
+//Save write the multi-sign transaction file
+func (this *MultiSignTx) Save(path string) error {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent error:%s", err)
+	}
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("WriteFile %s error:%s", path, err)
+	}
+	return nil
+}
+
+//GetPubKeys return the deserialized public keys of members
+func (this *MultiSignTx) GetPubKeys() ([]keypair.PublicKey, error) {
+	pubKeys := make([]keypair.PublicKey, 0, len(this.PubKeys))
+	for _, v := range this.PubKeys {
+		data, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("hex.DecodeString %s error:%s", v, err)
+		}
+		pubKey, err := keypair.DeserializePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("keypair.DeserializePublicKey %s error:%s", v, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+//GetTx return the deserialized transaction
+func (this *MultiSignTx) GetTx() (*types.MutableTransaction, error) {
+	data, err := hex.DecodeString(this.RawTx)
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString RawTx error:%s", err)
+	}
+	tx, err := types.TransactionFromRawBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("TransactionFromRawBytes error:%s", err)
+	}
+	mutable, err := tx.IntoMutable()
+	if err != nil {
+		return nil, fmt.Errorf("IntoMutable error:%s", err)
+	}
+	return mutable, nil
+}
+
+//SetTx update RawTx, TxHash and Payer with tx
+func (this *MultiSignTx) SetTx(tx *types.MutableTransaction) error {
+	immutable, err := tx.IntoImmutable()
+	if err != nil {
+		return fmt.Errorf("IntoImmutable error:%s", err)
+	}
+	sink := scommon.NewZeroCopySink(nil)
+	immutable.Serialization(sink)
+	txHash := tx.Hash()
+	this.TxHash = txHash.ToHexString()
+	this.Payer = tx.Payer.ToBase58()
+	this.RawTx = hex.EncodeToString(sink.Bytes())
+	return nil
+}
+
+//Sign add the signature of signer to the transaction
+func (this *MultiSignTx) Sign(ontSdk *sdk.OntologySdk, signer *sdk.Account) error {
+	tx, err := this.GetTx()
+	if err != nil {
+		return err
+	}
+	pubKeys, err := this.GetPubKeys()
+	if err != nil {
+		return err
+	}
+	err = ontSdk.MultiSignToTransaction(tx, this.M, pubKeys, signer)
+	if err != nil {
+		return fmt.Errorf("MultiSignToTransaction error:%s", err)
+	}
+	return this.SetTx(tx)
+}
+
+//Status return members who have and have not signed, only valid signatures are counted
+func (this *MultiSignTx) Status() (*MultiSignStatus, error) {
+	tx, err := this.GetTx()
+	if err != nil {
+		return nil, err
+	}
+	pubKeys, err := this.GetPubKeys()
+	if err != nil {
+		return nil, err
+	}
+	status := &MultiSignStatus{
+		M: this.M,
+		N: len(pubKeys),
+	}
+	txHash := tx.Hash()
+	var sigData [][]byte
+	for _, sig := range tx.Sigs {
+		if len(sig.PubKeys) == len(pubKeys) {
+			sigData = append(sigData, sig.SigData...)
+		}
+	}
+	for i, pubKey := range pubKeys {
+		address := types.AddressFromPubKey(pubKey)
+		signed := false
+		for _, data := range sigData {
+			if signature.Verify(pubKey, txHash.ToArray(), data) == nil {
+				signed = true
+				break
+			}
+		}
+		if signed {
+			status.Signed = append(status.Signed, fmt.Sprintf("%s %s", address.ToBase58(), this.PubKeys[i]))
+		} else {
+			status.Unsigned = append(status.Unsigned, fmt.Sprintf("%s %s", address.ToBase58(), this.PubKeys[i]))
+		}
+	}
+	return status, nil
+}
+
+//exportTransaction write tx to the export file instead of sending it. The first tx of a run is written
+//to the export file, the following ones to <name>-2<ext>, <name>-3<ext>...
+func exportTransaction(tx *types.MutableTransaction, nativeTx *NativeTx) error {
+	if nativeTx == nil || len(nativeTx.PubKeys) == 0 {
+		return fmt.Errorf("only multi-sign transactions can be exported")
+	}
+	multiSignTx, err := NewMultiSignTx(tx, nativeTx.PubKeys, nativeTx)
+	if err != nil {
+		return err
+	}
+	exportCount++
+	path := config.DefConfig.Export
+	if exportCount > 1 {
+		ext := filepath.Ext(path)
+		path = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), exportCount, ext)
+	}
+	err = multiSignTx.Save(path)
+	if err != nil {
+		return err
+	}
+	log4.Info("Export tx %s %s to %s, %d of %d members need to sign", multiSignTx.TxHash, multiSignTx.Method, path, multiSignTx.M, len(multiSignTx.PubKeys))
+	return nil
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/howeyc/gopass"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+)
+
+//Password sources of config.Password
+const (
+	PASSWORD_PROMPT = "prompt"
+	PASSWORD_ENV    = "env"
+	PASSWORD_FILE   = "file"
+	PASSWORD_FD     = "fd"
+)
+
+//PASSWORD_ENV_PREFIX is the prefix of the environment variable holding the password of a wallet
+const PASSWORD_ENV_PREFIX = "ONTOLOGY_TOOL_PASSWORD_"
+
+//PasswordProvider give the password of a wallet
+type PasswordProvider interface {
+	//GetPassword return password of an account, name is the account path or the address of a key
+	GetPassword(name string) ([]byte, error)
+}
+
+//passwords is the provider of config.Password, created on first use
+var passwords PasswordProvider
+
+//accounts cache unlocked accounts by absolute wallet path and address for the lifetime of the run
+var accounts = &accountCache{accounts: make(map[string]*sdk.Account)}
+
+type accountCache struct {
+	lock     sync.Mutex
+	accounts map[string]*sdk.Account
+}
+
+//NewPasswordProvider create the provider of source: "prompt", "env", "file:<path>" or "fd:<n>"
+func NewPasswordProvider(source string) (PasswordProvider, error) {
+	kind, arg := source, ""
+	if index := strings.Index(source, ":"); index >= 0 {
+		kind, arg = source[:index], source[index+1:]
+	}
+	switch kind {
+	case PASSWORD_PROMPT, "":
+		return &promptPasswordProvider{}, nil
+	case PASSWORD_ENV:
+		return &envPasswordProvider{}, nil
+	case PASSWORD_FILE:
+		return newFilePasswordProvider(arg)
+	case PASSWORD_FD:
+		fd, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid password fd %s", arg)
+		}
+		return &fdPasswordProvider{fd: fd}, nil
+	default:
+		return nil, fmt.Errorf("unknown password source %s, should be %s, %s, %s:<path> or %s:<n>", source,
+			PASSWORD_PROMPT, PASSWORD_ENV, PASSWORD_FILE, PASSWORD_FD)
+	}
+}
+
+//GetPassword return password of wallet by the configured password source
+func GetPassword(name string) ([]byte, error) {
+	if passwords == nil {
+		provider, err := NewPasswordProvider(config.DefConfig.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwords = provider
+	}
+	return passwords.GetPassword(name)
+}
+
+//GetAccountByPassword return the unlocked account of account path "<wallet path>[#<address or label>]", the
+//default account of wallet if no selector is given. An account is unlocked once per run
+func GetAccountByPassword(ontSdk *sdk.OntologySdk, accountPath string) (*sdk.Account, error) {
+	_, accData, err := openAccountData(ontSdk, accountPath)
+	if err != nil {
+		return nil, err
+	}
+	path, _ := ParseAccountPath(accountPath)
+	key, err := filepath.Abs(path)
+	if err != nil {
+		key = path
+	}
+	key = key + ACCOUNT_SELECTOR_SEP + accData.Address
+	accounts.lock.Lock()
+	defer accounts.lock.Unlock()
+	if user, ok := accounts.accounts[key]; ok {
+		return user, nil
+	}
+	pwd, err := GetPassword(accountPath)
+	if err != nil {
+		return nil, fmt.Errorf("getPassword of %s error:%s", accountPath, err)
+	}
+	user, err := accData.GetAccount(pwd)
+	if err != nil {
+		return nil, fmt.Errorf("get account %s of wallet %s error:%s", accData.Address, path, err)
+	}
+	accounts.accounts[key] = user
+	return user, nil
+}
+
+//passwordNames return names to look up the password of an account path, the account path itself then its
+//wallet path
+func passwordNames(accountPath string) []string {
+	path, selector := ParseAccountPath(accountPath)
+	if selector == "" && path == accountPath {
+		return []string{accountPath}
+	}
+	return []string{accountPath, path}
+}
+
+//promptPasswordProvider read the password from the terminal. The prompt goes to stderr, so it does not mix with
+//method outputs on stdout
+type promptPasswordProvider struct{}
+
+func (this *promptPasswordProvider) GetPassword(name string) ([]byte, error) {
+	return gopass.GetPasswdPrompt(fmt.Sprintf("%s Password:", name), false, os.Stdin, os.Stderr)
+}
+
+//envPasswordProvider read the password of a wallet from config.PasswordEnv[name], or from
+//ONTOLOGY_TOOL_PASSWORD_<name in upper case, other characters than letters and digits replaced by _>.
+//For an account path with selector, the account path is looked up before its wallet path
+type envPasswordProvider struct{}
+
+func (this *envPasswordProvider) GetPassword(name string) ([]byte, error) {
+	envs := make([]string, 0)
+	for _, n := range passwordNames(name) {
+		if env, ok := config.DefConfig.PasswordEnv[n]; ok {
+			envs = append(envs, env)
+		}
+	}
+	for _, n := range passwordNames(name) {
+		envs = append(envs, PasswordEnvName(n))
+	}
+	for _, env := range envs {
+		if pwd, ok := os.LookupEnv(env); ok {
+			return []byte(pwd), nil
+		}
+	}
+	return nil, fmt.Errorf("environment variable %s not set", strings.Join(envs, " or "))
+}
+
+//PasswordEnvName return the default environment variable holding the password of wallet
+func PasswordEnvName(name string) string {
+	return PASSWORD_ENV_PREFIX + strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return '_'
+	}, name)
+}
+
+//filePasswordProvider read passwords from a json file of {"<account or wallet path>": "<password>"}, "*" is the
+//password of wallets not listed. The file must not be accessible by group or others
+type filePasswordProvider struct {
+	path      string
+	passwords map[string]string
+}
+
+func newFilePasswordProvider(path string) (*filePasswordProvider, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat password file error:%s", err)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("password file %s is accessible by group or others, mode %s, should be 0600 or 0400", path, info.Mode().Perm())
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile %s error:%s", path, err)
+	}
+	provider := &filePasswordProvider{path: path}
+	err = json.Unmarshal(data, &provider.passwords)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal password file %s error:%s", path, err)
+	}
+	return provider, nil
+}
+
+func (this *filePasswordProvider) GetPassword(name string) ([]byte, error) {
+	for _, n := range append(passwordNames(name), "*") {
+		if pwd, ok := this.passwords[n]; ok {
+			return []byte(pwd), nil
+		}
+	}
+	return nil, fmt.Errorf("no password of %s in %s", name, this.path)
+}
+
+//fdPasswordProvider read one passphrase from a file descriptor on first use, for every wallet
+type fdPasswordProvider struct {
+	fd   int
+	once sync.Once
+	pwd  []byte
+	err  error
+}
+
+func (this *fdPasswordProvider) GetPassword(name string) ([]byte, error) {
+	this.once.Do(func() {
+		file := os.NewFile(uintptr(this.fd), fmt.Sprintf("fd%d", this.fd))
+		if file == nil {
+			this.err = fmt.Errorf("invalid fd %d", this.fd)
+			return
+		}
+		defer file.Close()
+		line, err := readLine(file)
+		if err != nil {
+			this.err = fmt.Errorf("read password from fd %d error:%s", this.fd, err)
+			return
+		}
+		this.pwd = []byte(line)
+	})
+	return this.pwd, this.err
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	log4 "github.com/alecthomas/log4go"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/smartcontract/service/neovm"
+)
+
+//nodeGasPrice is the gas price reported by node, queried once per run
+var nodeGasPrice uint64
+
+//PreExecInfo is the pre-execution result of a transaction before it is sent
+type PreExecInfo struct {
+	TxHash   string
+	Contract string
+	Method   string
+	State    byte
+	//Gas is the estimated gas limit
+	Gas uint64
+	//Result is the decoded return value of the contract
+	Result   string
+	GasPrice uint64
+	GasLimit uint64
+}
+
+//PreExecTransaction pre-execute tx and return error if it fails. An unsigned multi-sign tx is pre-executed
+//with an empty signature of its multi-sign address, so the contract sees the same signers
+func PreExecTransaction(chain Chain, tx *types.MutableTransaction, nativeTx *NativeTx) (*PreExecInfo, error) {
+	preTx := tx
+	if len(tx.Sigs) == 0 && nativeTx != nil && len(nativeTx.PubKeys) > 0 {
+		copyTx := *tx
+		copyTx.Sigs = []types.Sig{{PubKeys: nativeTx.PubKeys, M: nativeTx.MultiSignM()}}
+		preTx = &copyTx
+	}
+	txHash := tx.Hash()
+	info := &PreExecInfo{
+		TxHash:   txHash.ToHexString(),
+		GasPrice: tx.GasPrice,
+		GasLimit: tx.GasLimit,
+	}
+	if nativeTx != nil {
+		info.Contract = ContractName(nativeTx.Contract)
+		info.Method = nativeTx.Method
+	}
+	res, err := chain.PreExecTransaction(preTx)
+	if err != nil {
+		return info, fmt.Errorf("pre-execute %s %s error:%s", info.Contract, info.Method, err)
+	}
+	info.State = res.State
+	info.Gas = res.Gas
+	info.Result = decodeResultItem(res.Result)
+	if res.State == 0 {
+		return info, fmt.Errorf("pre-execute %s %s failed, state 0", info.Contract, info.Method)
+	}
+	return info, nil
+}
+
+//EstimateGasLimit return the estimated gas plus config.GasLimitMargin percent, not less than the minimum
+//transaction gas
+func EstimateGasLimit(gas uint64) uint64 {
+	gasLimit := gas * (100 + config.DefConfig.GasLimitMargin) / 100
+	if gasLimit < neovm.MIN_TRANSACTION_GAS {
+		gasLimit = neovm.MIN_TRANSACTION_GAS
+	}
+	return gasLimit
+}
+
+//GetNodeGasPrice return the minimum gas price of node, queried once per run
+func GetNodeGasPrice() (uint64, error) {
+	if nodeGasPrice > 0 {
+		return nodeGasPrice, nil
+	}
+	type gasPriceResult struct {
+		GasPrice uint64 `json:"gasprice"`
+		Height   uint32 `json:"height"`
+	}
+	result := &gasPriceResult{}
+	err := queryNode("getgasprice", "/api/v1/gasprice", result)
+	if err != nil {
+		return 0, err
+	}
+	nodeGasPrice = result.GasPrice
+	log4.Info("Gas price of node is %d at height %d", result.GasPrice, result.Height)
+	return nodeGasPrice, nil
+}
+
+//queryNode call a node api which is not covered by sdk, by rest if it is the transport, otherwise by json rpc.
+//With several endpoints the current one is used. Websocket transport falls back to JsonRpcAddress or
+//RestfulAddress
+func queryNode(rpcMethod, restPath string, result interface{}) error {
+	if endpoints != nil {
+		if config.DefConfig.Transport == config.TRANSPORT_REST {
+			return callRest(endpoints.currentAddress()+restPath, result)
+		}
+		return callRpc(endpoints.currentAddress(), rpcMethod, []interface{}{}, result)
+	}
+	if config.DefConfig.Transport == config.TRANSPORT_REST || config.DefConfig.JsonRpcAddress == "" {
+		if config.DefConfig.RestfulAddress == "" {
+			return fmt.Errorf("%s needs JsonRpcAddress or RestfulAddress", rpcMethod)
+		}
+		return callRest(config.DefConfig.RestfulAddress+restPath, result)
+	}
+	return callRpc(config.DefConfig.JsonRpcAddress, rpcMethod, []interface{}{}, result)
+}
+
+//callRest get a restful api of ontology node
+func callRest(url string, result interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("get %s error:%s", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read %s response error:%s", url, err)
+	}
+	return parseNodeResponse(url, body, result)
+}
+
+//callRpc call a json rpc method of ontology node which is not covered by sdk
+func callRpc(address, method string, params []interface{}, result interface{}) error {
+	req, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return fmt.Errorf("json.Marshal error:%s", err)
+	}
+	client := &http.Client{Transport: rpcTransport, Timeout: 10 * time.Second}
+	resp, err := client.Post(address, "application/json", bytes.NewReader(req))
+	if err != nil {
+		return fmt.Errorf("rpc %s error:%s", method, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read rpc %s response error:%s", method, err)
+	}
+	return parseNodeResponse(method, body, result)
+}
+
+//parseNodeResponse parse the result of a rpc or rest response, both have error, desc and result fields
+func parseNodeResponse(api string, body []byte, result interface{}) error {
+	nodeResp := &struct {
+		Error  int64           `json:"error"`
+		Desc   string          `json:"desc"`
+		Result json.RawMessage `json:"result"`
+	}{}
+	err := json.Unmarshal(body, nodeResp)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal %s response:%s error:%s", api, body, err)
+	}
+	if nodeResp.Error != 0 {
+		return fmt.Errorf("%s error code:%d desc:%s", api, nodeResp.Error, nodeResp.Desc)
+	}
+	err = json.Unmarshal(nodeResp.Result, result)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal %s result:%s error:%s", api, nodeResp.Result, err)
+	}
+	return nil
+}
+
+//decodeResultItem decode a pre-execution result: 01 and 00 as bool, printable bytes as string, others as hex
+func decodeResultItem(item *sdkcom.ResultItem) string {
+	if item == nil {
+		return ""
+	}
+	items, err := item.ToArray()
+	if err == nil {
+		values := make([]string, 0, len(items))
+		for _, v := range items {
+			values = append(values, decodeResultItem(v))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	data, err := item.ToByteArray()
+	if err != nil {
+		return ""
+	}
+	if len(data) == 1 && data[0] <= 1 {
+		return fmt.Sprintf("%t", data[0] == 1)
+	}
+	return formatBytes(data)
+}
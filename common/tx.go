@@ -0,0 +1,280 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	log4 "github.com/alecthomas/log4go"
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//NativeTx describe a native contract invocation and who sign it
+type NativeTx struct {
+	GasPrice uint64
+	GasLimit uint64
+	Version  byte
+	Contract scommon.Address
+	Method   string
+	Params   []interface{}
+	//Payer of tx, default is the first signer
+	Payer *sdk.Account
+	//Signers sign tx one by one
+	Signers []*sdk.Account
+	//PubKeys of the multi-sign address
+	PubKeys []keypair.PublicKey
+	//M of the multi-sign address, default is MultiSignM(len(PubKeys))
+	M uint16
+	//MultiSigners sign tx for the multi-sign address of PubKeys, tx is left unsigned if empty
+	MultiSigners []*sdk.Account
+}
+
+//MultiSignM return M of the multi-sign address of PubKeys
+func (this *NativeTx) MultiSignM() uint16 {
+	if this.M == 0 {
+		return MultiSignM(len(this.PubKeys))
+	}
+	return this.M
+}
+
+//Build build the transaction and sign it
+func (this *NativeTx) Build() (*types.MutableTransaction, error) {
+	tx, err := txBuilder.Native.NewNativeInvokeTransaction(this.GasPrice, this.GasLimit, this.Version, this.Contract, this.Method, this.Params)
+	if err != nil {
+		return nil, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
+	}
+	if this.Payer != nil {
+		txBuilder.SetPayer(tx, this.Payer.Address)
+		err = txBuilder.SignToTransaction(tx, this.Payer)
+		if err != nil {
+			return nil, fmt.Errorf("SignToTransaction error:%s", err)
+		}
+	}
+	for _, signer := range this.Signers {
+		err = txBuilder.SignToTransaction(tx, signer)
+		if err != nil {
+			return nil, fmt.Errorf("SignToTransaction error:%s", err)
+		}
+	}
+	if len(this.PubKeys) > 0 && tx.Payer == scommon.ADDRESS_EMPTY {
+		_, payer, err := MultiSignAddress(this.PubKeys, this.MultiSignM(), "")
+		if err != nil {
+			return nil, err
+		}
+		tx.Payer = payer
+	}
+	for _, signer := range this.MultiSigners {
+		err = txBuilder.MultiSignToTransaction(tx, this.MultiSignM(), this.PubKeys, signer)
+		if err != nil {
+			return nil, fmt.Errorf("MultiSignToTransaction error:%s", err)
+		}
+	}
+	return tx, nil
+}
+
+//SendNativeTx build, sign, pre-execute and send a native invoke transaction. Nothing is sent if pre-execution
+//fails. Gas price and gas limit are taken from node and from the estimate if configured, the transaction is
+//built and signed again when the estimate changes its gas limit
+func SendNativeTx(chain Chain, nativeTx *NativeTx) (scommon.Uint256, error) {
+	if config.DefConfig.GasPriceFromNode {
+		gasPrice, err := GetNodeGasPrice()
+		if err != nil {
+			return scommon.UINT256_EMPTY, fmt.Errorf("GetNodeGasPrice error:%s", err)
+		}
+		nativeTx.GasPrice = gasPrice
+	}
+	tx, err := nativeTx.Build()
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	preExec, err := PreExecTransaction(chain, tx, nativeTx)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	if config.DefConfig.EstimateGasLimit {
+		gasLimit := EstimateGasLimit(preExec.Gas)
+		if gasLimit != nativeTx.GasLimit {
+			nativeTx.GasLimit = gasLimit
+			tx, err = nativeTx.Build()
+			if err != nil {
+				return scommon.UINT256_EMPTY, err
+			}
+			txHash := tx.Hash()
+			preExec.TxHash = txHash.ToHexString()
+			preExec.GasLimit = gasLimit
+		}
+	}
+	if preExec.Gas > nativeTx.GasLimit {
+		return scommon.UINT256_EMPTY, fmt.Errorf("%s %s estimated gas %d exceeds gas limit %d", preExec.Contract, preExec.Method, preExec.Gas, nativeTx.GasLimit)
+	}
+	sentTxs.addPreExec(preExec)
+	log4.Info("PreExec %s %s gas:%d result:%s, send with gas price:%d gas limit:%d", preExec.Contract, preExec.Method, preExec.Gas, preExec.Result, preExec.GasPrice, preExec.GasLimit)
+	return SendTransaction(chain, tx, nativeTx)
+}
+
+//SendTransaction is the single place transactions are sent to ontology. In dry-run mode the transaction
+//is printed instead, in export mode it is written to the export file, and its hash is returned as if it
+//was sent. nativeTx describe the payload, can be nil
+func SendTransaction(chain Chain, tx *types.MutableTransaction, nativeTx *NativeTx) (scommon.Uint256, error) {
+	if config.DefConfig.Export != "" {
+		err := exportTransaction(tx, nativeTx)
+		if err != nil {
+			return scommon.UINT256_EMPTY, err
+		}
+		return tx.Hash(), nil
+	}
+	if config.DefConfig.DryRun {
+		err := PrintTransaction(os.Stdout, tx, nativeTx)
+		if err != nil {
+			return scommon.UINT256_EMPTY, err
+		}
+		return tx.Hash(), nil
+	}
+	txHash, err := broadcastTransaction(chain, tx)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	sentTxs.add(tx)
+	return txHash, nil
+}
+
+//PrintTransaction print the decoded payload, signers, gas and raw hex of tx
+func PrintTransaction(w io.Writer, tx *types.MutableTransaction, nativeTx *NativeTx) error {
+	immutable, err := tx.IntoImmutable()
+	if err != nil {
+		return fmt.Errorf("IntoImmutable error:%s", err)
+	}
+	sink := scommon.NewZeroCopySink(nil)
+	immutable.Serialization(sink)
+	txHash := tx.Hash()
+
+	fmt.Fprintln(w, "===============================================================")
+	fmt.Fprintln(w, "Dry run, transaction not sent")
+	fmt.Fprintf(w, "TxHash:   %s\n", txHash.ToHexString())
+	if nativeTx != nil {
+		fmt.Fprintf(w, "Contract: %s (%s)\n", nativeTx.Contract.ToHexString(), ContractName(nativeTx.Contract))
+		fmt.Fprintf(w, "Method:   %s\n", nativeTx.Method)
+		fmt.Fprintln(w, "Params:")
+		for _, param := range nativeTx.Params {
+			printValue(w, "  ", "-", reflect.ValueOf(param))
+		}
+	}
+	fmt.Fprintf(w, "Payer:    %s\n", tx.Payer.ToBase58())
+	fmt.Fprintln(w, "Signers:")
+	for _, sig := range tx.Sigs {
+		address, err := types.AddressFromMultiPubKeys(sig.PubKeys, int(sig.M))
+		if len(sig.PubKeys) == 1 {
+			address = types.AddressFromPubKey(sig.PubKeys[0])
+			err = nil
+		}
+		if err != nil {
+			return fmt.Errorf("AddressFromMultiPubKeys error:%s", err)
+		}
+		fmt.Fprintf(w, "  - %s %d-of-%d signed:%d\n", address.ToBase58(), sig.M, len(sig.PubKeys), len(sig.SigData))
+	}
+	fmt.Fprintf(w, "GasPrice: %d\n", tx.GasPrice)
+	fmt.Fprintf(w, "GasLimit: %d\n", tx.GasLimit)
+	fmt.Fprintf(w, "RawTx:    %s\n", hex.EncodeToString(sink.Bytes()))
+	fmt.Fprintln(w, "===============================================================")
+	return nil
+}
+
+//ContractName return name of a native contract, or "unknown"
+func ContractName(contract scommon.Address) string {
+	switch contract {
+	case utils.OntContractAddress:
+		return "ont"
+	case utils.OngContractAddress:
+		return "ong"
+	case utils.OntIDContractAddress:
+		return "ontid"
+	case utils.ParamContractAddress:
+		return "param"
+	case utils.AuthContractAddress:
+		return "auth"
+	case utils.GovernanceContractAddress:
+		return "governance"
+	}
+	return "unknown"
+}
+
+//printValue print v as indented "name: value" lines. Addresses are printed in base58,
+//printable bytes as string and other bytes as hex
+func printValue(w io.Writer, indent, name string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			fmt.Fprintf(w, "%s%s <nil>\n", indent, name)
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Type() == reflect.TypeOf(scommon.Address{}) {
+		address := v.Interface().(scommon.Address)
+		fmt.Fprintf(w, "%s%s %s\n", indent, name, address.ToBase58())
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		fmt.Fprintf(w, "%s%s\n", indent, name)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			printValue(w, indent+"  ", v.Type().Field(i).Name+":", v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			fmt.Fprintf(w, "%s%s %s\n", indent, name, formatBytes(data))
+			return
+		}
+		fmt.Fprintf(w, "%s%s\n", indent, name)
+		for i := 0; i < v.Len(); i++ {
+			printValue(w, indent+"  ", "-", v.Index(i))
+		}
+	default:
+		fmt.Fprintf(w, "%s%s %v\n", indent, name, v.Interface())
+	}
+}
+
+func formatBytes(data []byte) string {
+	if len(data) == 0 {
+		return `""`
+	}
+	printable := utf8.Valid(data) && strings.IndexFunc(string(data), func(r rune) bool {
+		return !unicode.IsPrint(r)
+	}) < 0
+	if printable {
+		return fmt.Sprintf("%q", data)
+	}
+	return hex.EncodeToString(data)
+}
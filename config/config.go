@@ -25,8 +25,41 @@ import (
 	log4 "github.com/alecthomas/log4go"
 	"io/ioutil"
 	"os"
+	"sort"
 )
 
+//DEFAULT_CONFIRM_TIMEOUT is the default seconds to wait a transaction confirmed
+const DEFAULT_CONFIRM_TIMEOUT = 30
+
+//DEFAULT_GAS_LIMIT_MARGIN is the default percent added to estimated gas limit
+const DEFAULT_GAS_LIMIT_MARGIN = 20
+
+//Transports to connect ontology
+const (
+	TRANSPORT_RPC  = "rpc"
+	TRANSPORT_REST = "rest"
+	TRANSPORT_WS   = "ws"
+)
+
+//Formats of method outputs
+const (
+	OUTPUT_TABLE = "table"
+	OUTPUT_JSON  = "json"
+	OUTPUT_CSV   = "csv"
+)
+
+//DEFAULT_MAX_HEIGHT_DIFF is the default blocks an endpoint can lag behind the highest one
+const DEFAULT_MAX_HEIGHT_DIFF = 3
+
+//DEFAULT_WALLET is the default wallet of account paths without wallet path
+const DEFAULT_WALLET = "./wallet.dat"
+
+//DEFAULT_DEVNET_RPC_PORT is the default json rpc port of devnet
+const DEFAULT_DEVNET_RPC_PORT = 20336
+
+//DEFAULT_DEVNET_BLOCK_TIME is the default seconds between devnet blocks
+const DEFAULT_DEVNET_BLOCK_TIME = 1
+
 //Default config instance
 var DefConfig = NewConfig()
 
@@ -38,6 +71,15 @@ type Config struct {
 	RestfulAddress string
 	//WebSocketAddress of ontology
 	WebSocketAddress string
+	//Transport to connect ontology, TRANSPORT_RPC, TRANSPORT_REST or TRANSPORT_WS, default is TRANSPORT_RPC
+	Transport string
+	//Confirm transactions by smart contract events pushed on WebSocketAddress instead of polling
+	ConfirmByWebSocket bool
+	//Ordered addresses of rpc or rest transport. Reads go to the first healthy one, and a broadcast which can
+	//not reach a node fails over to the next. Default is the single address of transport
+	Endpoints []string
+	//Blocks an endpoint can lag behind the highest one and still be healthy, default is DEFAULT_MAX_HEIGHT_DIFF
+	MaxHeightDiff uint32
 
 	//Gas Price of transaction
 	GasPrice uint64
@@ -45,11 +87,136 @@ type Config struct {
 	GasLimit uint64
 	//Gas Limit of deploy transaction
 	GasDeployLimit uint64
+	//Set gas limit of invoke transaction to the pre-execution estimate plus GasLimitMargin percent
+	EstimateGasLimit bool
+	//Percent added to estimated gas limit, default is DEFAULT_GAS_LIMIT_MARGIN
+	GasLimitMargin uint64
+	//Use the minimum gas price reported by node instead of GasPrice
+	GasPriceFromNode bool
+
+	//Seconds to wait a sent transaction confirmed, default is DEFAULT_CONFIRM_TIMEOUT
+	ConfirmTimeout uint32
+	//Number of blocks on top of the block including a transaction before it is confirmed, 0 means included
+	ConfirmDepth uint32
+
+	//Wallet of account paths without wallet path, such as "#peer3", default is DEFAULT_WALLET
+	Wallet string
+	//Named signer groups of multi-sign methods, referred by "Signers" in params
+	Signers map[string]*SignerGroup
+	//Source of wallet passwords: "prompt" (default), "env", "file:<path>" or "fd:<n>"
+	Password string
+	//Environment variable holding the password of a wallet path, for "env" password source
+	PasswordEnv map[string]string
+
+	//Expected hash of block 0, checked after connecting so a profile can not send to another chain
+	GenesisHash string
+	//Protected network asks typed confirmation before every method sending transactions
+	Protected bool
+	//Methods allowed to run, all methods if empty
+	AllowedMethods []string
+	//Named network profiles, selected by Network
+	Networks map[string]*NetworkProfile
+	//Name of selected network profile, empty to use the flat config
+	Network string
+
+	//Build and sign transactions, print them instead of sending
+	DryRun bool
+	//Write unsigned multi-sign transactions to this file instead of sending
+	Export string
+	//Format of method outputs, OUTPUT_TABLE, OUTPUT_JSON or OUTPUT_CSV, default is OUTPUT_TABLE
+	Output string
+
+	//Devnet is the in-process single node chain started by -devnet
+	Devnet *DevnetConfig
+}
+
+//DevnetConfig describe the genesis and the node of devnet
+type DevnetConfig struct {
+	//DataDir of the ledger, a new chain in a temporary directory if empty. An existing ledger must have the
+	//same genesis
+	DataDir string
+	//JsonRpcPort of the local json rpc endpoint, default is DEFAULT_DEVNET_RPC_PORT
+	JsonRpcPort uint
+	//Seconds between blocks, default is DEFAULT_DEVNET_BLOCK_TIME
+	GenBlockTime uint
+	//Bookkeepers are account paths, the first one produces blocks. They hold the genesis ONT and are the
+	//governance admin, by their (5n+6)/7 multi-sign address if more than one. Default is the default account
+	//of Wallet
+	Bookkeepers []string
+	//Peers of the genesis consensus set, there must be at least 7 of them. Default is 7 generated peers owned
+	//by the first bookkeeper
+	Peers []*DevnetPeer
+	//N, C, L, MaxBlockChangeView and MinInitStake of the genesis vbft config, mainnet genesis values if 0.
+	//K is the number of peers
+	N                  uint32
+	C                  uint32
+	L                  uint32
+	MaxBlockChangeView uint32
+	MinInitStake       uint32
+	//StartHeight is the height of empty blocks added at once when the chain starts, before blocks are produced
+	//every GenBlockTime. Governance methods such as changeMaxAuthorization, setFeePercentage and withdrawFee
+	//need height 414100, the mainnet height of the new governance version
+	StartHeight uint32
+	//CandidateFee of global param in ONG with 9 decimals, set by the governance admin once the chain starts.
+	//Default is 0 since there is no ONG on devnet
+	CandidateFee uint64
+	//Fund are ONT transfers from the bookkeepers once the chain starts
+	Fund []*DevnetFund
+}
+
+//DevnetPeer is a consensus peer of devnet genesis
+type DevnetPeer struct {
+	//PeerPubkey in hex
+	PeerPubkey string
+	//Address of the owner, default is the first bookkeeper
+	Address string
+	//InitPos of the peer, default is MinInitStake
+	InitPos uint64
+}
+
+//DevnetFund is ONT transferred to an address when devnet starts
+type DevnetFund struct {
+	//Address in base58, or an account path
+	Address string
+	Ont     uint64
+}
+
+//SignerGroup is a named multi-sign address and the wallets signing for it
+type SignerGroup struct {
+	//Path are the wallets of members, or of signers if PubKeys is given
+	Path []string
+	//PubKeys of all members, default is the public keys of Path
+	PubKeys []string
+	//M of the multi-sign address, default is (5n+6)/7
+	M uint16
+	//ExpectedAddress is checked against the derived multi-sign address if given
+	ExpectedAddress string
+}
+
+//NetworkProfile is a named network. Non-empty fields override the flat config when it is selected
+type NetworkProfile struct {
+	JsonRpcAddress   string
+	RestfulAddress   string
+	WebSocketAddress string
+	Transport        string
+	Endpoints        []string
+	GasPrice         uint64
+	GasLimit         uint64
+	GenesisHash      string
+	Protected        bool
+	AllowedMethods   []string
 }
 
 //NewConfig retuen a Config instance
 func NewConfig() *Config {
-	return &Config{}
+	return &Config{
+		Transport:      TRANSPORT_RPC,
+		Output:         OUTPUT_TABLE,
+		MaxHeightDiff:  DEFAULT_MAX_HEIGHT_DIFF,
+		Wallet:         DEFAULT_WALLET,
+		GasLimitMargin: DEFAULT_GAS_LIMIT_MARGIN,
+		ConfirmTimeout: DEFAULT_CONFIRM_TIMEOUT,
+	}
 }
 
 //Init Config with a config file
@@ -61,6 +228,73 @@ func (this *Config) Init(fileName string) error {
 	return nil
 }
 
+//SelectNetwork apply the network profile of name over the flat config. Addresses of the flat config are
+//dropped if the profile gives any address. Empty name select Network of config file, if any
+func (this *Config) SelectNetwork(name string) error {
+	if name == "" {
+		name = this.Network
+	}
+	if name == "" {
+		return nil
+	}
+	profile, ok := this.Networks[name]
+	if !ok {
+		names := make([]string, 0, len(this.Networks))
+		for n := range this.Networks {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("network %s not found in config, networks:%v", name, names)
+	}
+	this.Network = name
+	if profile.JsonRpcAddress != "" || profile.RestfulAddress != "" || profile.WebSocketAddress != "" || len(profile.Endpoints) > 0 {
+		this.JsonRpcAddress = profile.JsonRpcAddress
+		this.RestfulAddress = profile.RestfulAddress
+		this.WebSocketAddress = profile.WebSocketAddress
+		this.Endpoints = profile.Endpoints
+	}
+	if profile.Transport != "" {
+		this.Transport = profile.Transport
+	}
+	if profile.GasPrice != 0 {
+		this.GasPrice = profile.GasPrice
+	}
+	if profile.GasLimit != 0 {
+		this.GasLimit = profile.GasLimit
+	}
+	if profile.GenesisHash != "" {
+		this.GenesisHash = profile.GenesisHash
+	}
+	if profile.Protected {
+		this.Protected = true
+	}
+	if len(profile.AllowedMethods) > 0 {
+		this.AllowedMethods = profile.AllowedMethods
+	}
+	return nil
+}
+
+//MethodAllowed return true if AllowedMethods is empty or contains method
+func (this *Config) MethodAllowed(method string) bool {
+	if len(this.AllowedMethods) == 0 {
+		return true
+	}
+	for _, allowed := range this.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+//NetworkName return name of selected network, "default" if the flat config is used
+func (this *Config) NetworkName() string {
+	if this.Network == "" {
+		return "default"
+	}
+	return this.Network
+}
+
 func (this *Config) loadConfig(fileName string) error {
 	data, err := this.readFile(fileName)
 	if err != nil {
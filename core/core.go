@@ -19,61 +19,309 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	log4 "github.com/alecthomas/log4go"
 	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
 	"github.com/ontio/ontology-tool/config"
 )
 
 var OntTool = NewOntologyTool()
 
-type Method func(sdk *sdk.OntologySdk) bool
+//Status of a method invocation
+const (
+	STATUS_PASS = "pass"
+	STATUS_FAIL = "fail"
+	STATUS_SKIP = "skip"
+)
+
+//MethodResult is the outcome of one method invocation
+type MethodResult struct {
+	Index    int
+	Name     string
+	Label    string
+	Status   string
+	Start    time.Time
+	Duration time.Duration
+	TxHashes []string
+	Outputs  []*Output
+	Warnings []string
+	Error    string
+}
+
+//DEFAULT_PARAMS_DIR is the directory of default params files
+const DEFAULT_PARAMS_DIR = "./params"
 
 type OntologyTool struct {
 	//Map name to method
-	methodsMap map[string]Method
-	//Map method result
-	methodsRes map[string]bool
+	methodsMap map[string]*MethodInfo
+	//Result of every method invocation, in run order
+	methodsRes []*MethodResult
+	//Directory of default params files
+	paramsDir string
+	//Report file prefix, no report will be written if empty
+	report string
+	//Chain to run methods on instead of the node of config, such as a fake chain
+	chain common.Chain
 }
 
 func NewOntologyTool() *OntologyTool {
 	return &OntologyTool{
-		methodsMap: make(map[string]Method, 0),
-		methodsRes: make(map[string]bool, 0),
+		methodsMap: make(map[string]*MethodInfo, 0),
+		methodsRes: make([]*MethodResult, 0),
+		paramsDir:  DEFAULT_PARAMS_DIR,
 	}
 }
 
-func (this *OntologyTool) RegMethod(name string, method Method) {
-	this.methodsMap[name] = method
+//RegMethod register a method with its description
+func (this *OntologyTool) RegMethod(info *MethodInfo) {
+	this.methodsMap[info.Name] = info
 }
 
-//Start run
-func (this *OntologyTool) Start(methodsList []string) {
-	if len(methodsList) > 0 {
-		this.runMethodList(methodsList)
-		return
+//SetReport set the prefix of report files. <prefix>.json and <prefix>.xml will be written when run finish
+func (this *OntologyTool) SetReport(prefix string) {
+	this.report = prefix
+}
+
+//SetParamsDir set the directory of default params files, <dir>/<Method>.json is used if no params given
+func (this *OntologyTool) SetParamsDir(dir string) {
+	this.paramsDir = dir
+}
+
+//SetChain run methods on chain instead of connecting to the node of config
+func (this *OntologyTool) SetChain(chain common.Chain) {
+	this.chain = chain
+}
+
+//Start run, return false if any method did not pass. Steps not started yet are skipped when ctx is done.
+//Nothing is run if any step refers to an unregistered method
+func (this *OntologyTool) Start(ctx context.Context, steps []*Step) bool {
+	err := this.CheckSteps(steps)
+	if err != nil {
+		log4.Error("CheckSteps error:%s", err)
+		//rejected steps fail in the report with their own errors and the others are not run, so a rejected run
+		//is not mistaken for one without report
+		for i, step := range steps {
+			problems := this.checkStep(step)
+			if len(problems) == 0 {
+				this.skipMethod(i+1, step, "not run, other steps rejected")
+				continue
+			}
+			this.addMethodResult(i+1, step, STATUS_FAIL, fmt.Sprintf("check step error:%s", strings.Join(problems, "; ")))
+		}
+		this.writeReport()
+		return false
+	}
+	if len(steps) > 0 {
+		return this.runSteps(ctx, steps)
 	}
 	log4.Info("No method to run")
-	return
+	return true
 }
 
-func (this *OntologyTool) runMethodList(methodsList []string) {
+func (this *OntologyTool) runSteps(ctx context.Context, steps []*Step) bool {
 	this.onStart()
-	defer this.onFinish(methodsList)
-	ontSdk := sdk.NewOntologySdk()
-	ontSdk.NewRpcClient().SetAddress(config.DefConfig.JsonRpcAddress)
-	for i, method := range methodsList {
-		this.runMethod(i+1, ontSdk, method)
+	defer this.onFinish(steps)
+	ontSdk, chain, err := this.connect()
+	if err != nil {
+		log4.Error("NewOntologySdk error:%s", err)
+		for i, step := range steps {
+			this.skipMethod(i+1, step, fmt.Sprintf("connect ontology error:%s", err))
+		}
+		return false
+	}
+	stopped := false
+	for i, step := range steps {
+		if stopped {
+			this.skipMethod(i+1, step, "previous step failed")
+			continue
+		}
+		if ctx.Err() != nil {
+			this.skipMethod(i+1, step, "run canceled")
+			continue
+		}
+		ok := this.runMethod(ctx, i+1, ontSdk, chain, step)
+		if !ok && !step.ContinueOnError {
+			stopped = true
+		}
+	}
+	for _, res := range this.methodsRes {
+		if res.Status != STATUS_PASS {
+			return false
+		}
+	}
+	return true
+}
+
+//connect return the sdk and the chain to run methods on. The sdk is only used for wallets if a chain is set
+func (this *OntologyTool) connect() (*sdk.OntologySdk, common.Chain, error) {
+	if this.chain != nil {
+		return sdk.NewOntologySdk(), this.chain, nil
+	}
+	ontSdk, err := common.NewOntologySdk()
+	if err != nil {
+		return nil, nil, err
+	}
+	return ontSdk, ontSdk, nil
+}
+
+func (this *OntologyTool) runMethod(ctx context.Context, index int, sdk *sdk.OntologySdk, chain common.Chain, step *Step) bool {
+	info := this.getMethodByName(step.Method)
+	if info == nil {
+		log4.Error("Method:%s not registered", step.Method)
+		this.skipMethod(index, step, "method not registered")
+		return false
+	}
+	err := confirmProtected(info)
+	if err != nil {
+		log4.Error("Method:%s %s", step.Name(), err)
+		this.skipMethod(index, step, err.Error())
+		return false
+	}
+	this.onBeforeMethodStart(index, step.Name())
+	res := &MethodResult{
+		Index: index,
+		Name:  step.Method,
+		Label: step.Label,
+		Start: time.Now(),
+	}
+	this.methodsRes = append(this.methodsRes, res)
+	env := &Env{
+		Sdk:    sdk,
+		Chain:  chain,
+		Config: config.DefConfig,
+		Params: step.ParamSource(this.paramsDir),
+		Logger: log4.Global,
+	}
+	result, err := info.Method(ctx, env)
+	if result == nil {
+		result = NewResult()
+	}
+	renderErr := RenderOutputs(os.Stdout, config.DefConfig.Output, result.Outputs)
+	if renderErr != nil {
+		log4.Error("Method:%s render outputs error:%s", step.Name(), renderErr)
+	}
+	for _, txHash := range result.TxHashes {
+		if preExec := common.GetPreExec(txHash); preExec != nil {
+			result.AddOutput("preExec", preExec)
+		}
+	}
+	confirmErr := confirmTxs(ctx, chain, result)
+	if err == nil {
+		err = confirmErr
+	}
+	if err == nil && step.Wait != nil {
+		err = waitAfterStep(ctx, chain, step.Wait)
+		if err != nil {
+			err = fmt.Errorf("wait error:%s", err)
+		}
+	}
+	res.Duration = time.Since(res.Start)
+	res.TxHashes = result.TxHashes
+	res.Outputs = result.Outputs
+	if config.DefConfig.Export != "" && len(result.TxHashes) > 0 {
+		result.AddWarning(fmt.Sprintf("export, %d transactions not sent", len(result.TxHashes)))
+	} else if config.DefConfig.DryRun && len(result.TxHashes) > 0 {
+		result.AddWarning(fmt.Sprintf("dry run, %d transactions not sent", len(result.TxHashes)))
+	}
+	res.Warnings = result.Warnings
+	for _, warning := range result.Warnings {
+		log4.Warn("Method:%s warning:%s", step.Name(), warning)
+	}
+	if err == nil {
+		res.Status = STATUS_PASS
+	} else {
+		res.Status = STATUS_FAIL
+		res.Error = err.Error()
+		log4.Error("Method:%s error:%s", step.Name(), err)
 	}
+	this.onAfterMethodFinish(index, step.Name(), err == nil)
+	return err == nil
 }
 
-func (this *OntologyTool) runMethod(index int, sdk *sdk.OntologySdk, methodName string) {
-	this.onBeforeMethodStart(index, methodName)
-	method := this.getMethodByName(methodName)
-	if method != nil {
-		ok := method(sdk)
-		this.onAfterMethodFinish(index, methodName, ok)
-		this.methodsRes[methodName] = ok
+//confirmProtected ask the user to type the network name before a method sends transactions on a protected
+//network. Nothing to confirm if transactions are not sent
+func confirmProtected(info *MethodInfo) error {
+	if !config.DefConfig.Protected || !info.SendTx || common.TxNotSent() {
+		return nil
 	}
+	network := config.DefConfig.NetworkName()
+	prompt := fmt.Sprintf("Method %s sends transactions on protected network %s, type the network name to continue:", info.Name, network)
+	err := common.ConfirmTyped(prompt, network)
+	if err != nil {
+		return fmt.Errorf("not confirmed on protected network %s: %s", network, err)
+	}
+	return nil
+}
+
+func (this *OntologyTool) skipMethod(index int, step *Step, reason string) {
+	this.addMethodResult(index, step, STATUS_SKIP, reason)
+}
+
+//addMethodResult record a step which did not run, with status and the reason as error
+func (this *OntologyTool) addMethodResult(index int, step *Step, status string, reason string) {
+	this.methodsRes = append(this.methodsRes, &MethodResult{
+		Index:    index,
+		Name:     step.Method,
+		Label:    step.Label,
+		Status:   status,
+		Start:    time.Now(),
+		TxHashes: make([]string, 0),
+		Outputs:  make([]*Output, 0),
+		Warnings: make([]string, 0),
+		Error:    reason,
+	})
+}
+
+//confirmTxs wait every tx of result confirmed and add its execution result to outputs. Return the first
+//error, including txs failed on chain
+func confirmTxs(ctx context.Context, chain common.Chain, result *Result) error {
+	if common.TxNotSent() {
+		return nil
+	}
+	var firstErr error
+	for _, txHash := range result.TxHashes {
+		confirmation, err := common.ConfirmTx(ctx, chain, txHash)
+		if confirmation != nil {
+			result.AddOutput("confirmation", confirmation)
+			for _, event := range confirmation.Events {
+				log4.Info("Tx:%s event %s", txHash, event)
+			}
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			log4.Info("Tx:%s confirmed at height %d, gas consumed %d", txHash, confirmation.Height, confirmation.GasConsumed)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return firstErr
+}
+
+func waitAfterStep(ctx context.Context, chain common.Chain, wait *WaitPolicy) error {
+	if wait.Blocks > 0 {
+		timeout := time.Duration(wait.Timeout) * time.Second
+		if timeout == 0 {
+			timeout = time.Duration(wait.Blocks) * 30 * time.Second
+		}
+		err := common.WaitForBlocks(ctx, chain, wait.Blocks, timeout)
+		if err != nil {
+			return err
+		}
+	}
+	if wait.Seconds > 0 {
+		return common.Sleep(ctx, time.Duration(wait.Seconds)*time.Second)
+	}
+	return nil
 }
 
 func (this *OntologyTool) onStart() {
@@ -83,22 +331,18 @@ func (this *OntologyTool) onStart() {
 	log4.Info("")
 }
 
-func (this *OntologyTool) onFinish(methodsList []string) {
-	failedList := make([]string, 0)
-	successList := make([]string, 0)
-	for methodName, ok := range this.methodsRes {
-		if ok {
-			successList = append(successList, methodName)
-		} else {
-			failedList = append(failedList, methodName)
-		}
-	}
-
-	skipList := make([]string, 0)
-	for _, method := range methodsList {
-		_, ok := this.methodsRes[method]
-		if !ok {
-			skipList = append(skipList, method)
+func (this *OntologyTool) onFinish(steps []*Step) {
+	failedList := make([]*MethodResult, 0)
+	successList := make([]*MethodResult, 0)
+	skipList := make([]*MethodResult, 0)
+	for _, res := range this.methodsRes {
+		switch res.Status {
+		case STATUS_PASS:
+			successList = append(successList, res)
+		case STATUS_FAIL:
+			failedList = append(failedList, res)
+		default:
+			skipList = append(skipList, res)
 		}
 	}
 
@@ -107,32 +351,52 @@ func (this *OntologyTool) onFinish(methodsList []string) {
 
 	log4.Info("===============================================================")
 	log4.Info("Ontology Tool Finish Total:%v Success:%v Failed:%v Skip:%v",
-		len(methodsList),
+		len(steps),
 		succCount,
 		failedCount,
-		len(methodsList)-succCount-failedCount)
+		len(steps)-succCount-failedCount)
 	if succCount > 0 {
 		log4.Info("---------------------------------------------------------------")
 		log4.Info("Success list:")
 		for i, succ := range successList {
-			log4.Info("%d.\t%s", i+1, succ)
+			log4.Info("%d.\t%s", i+1, succ.displayName())
 		}
 	}
 	if failedCount > 0 {
 		log4.Info("---------------------------------------------------------------")
 		log4.Info("Fail list:")
 		for i, fail := range failedList {
-			log4.Info("%d.\t%s", i+1, fail)
+			log4.Info("%d.\t%s", i+1, fail.displayName())
 		}
 	}
 	if len(skipList) > 0 {
 		log4.Info("---------------------------------------------------------------")
 		log4.Info("Skip list:")
 		for i, skip := range skipList {
-			log4.Info("%d.\t%s", i+1, skip)
+			log4.Info("%d.\t%s", i+1, skip.displayName())
 		}
 	}
 	log4.Info("===============================================================")
+
+	this.writeReport()
+}
+
+//writeReport write the report of methodsRes if a report is set
+func (this *OntologyTool) writeReport() {
+	if this.report == "" {
+		return
+	}
+	err := writeReport(this.report, this.methodsRes)
+	if err != nil {
+		log4.Error("writeReport error:%s", err)
+	}
+}
+
+func (this *MethodResult) displayName() string {
+	if this.Label != "" {
+		return this.Label
+	}
+	return this.Name
 }
 
 func (this *OntologyTool) onBeforeMethodStart(index int, methodName string) {
@@ -151,6 +415,6 @@ func (this *OntologyTool) onAfterMethodFinish(index int, methodName string, res
 	log4.Info("")
 }
 
-func (this *OntologyTool) getMethodByName(name string) Method {
+func (this *OntologyTool) getMethodByName(name string) *MethodInfo {
 	return this.methodsMap[name]
 }
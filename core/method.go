@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"context"
+
+	log4 "github.com/alecthomas/log4go"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//Method is the contract of a tool method. A method should stop as soon as ctx is done,
+//and return the result collected so far together with the error
+type Method func(ctx context.Context, env *Env) (*Result, error)
+
+//Env is the run environment handed to a method
+type Env struct {
+	//Sdk open wallets and sign transactions, it is connected to ontology unless the tool runs on another chain
+	Sdk *sdk.OntologySdk
+	//Chain the methods read and send transactions to
+	Chain common.Chain
+	//Config of tool
+	Config *config.Config
+	//Params of method
+	Params *ParamSource
+	//Logger of method
+	Logger log4.Logger
+}
+
+//ClientOptions return the options of the clients a method uses: gas of Config, and transactions sent by
+//common.SendNativeTx, so gas price from node, gas estimate, endpoints, dry run and export of the run apply
+func (this *Env) ClientOptions() *client.Options {
+	return &client.Options{
+		GasPrice: this.Config.GasPrice,
+		GasLimit: this.Config.GasLimit,
+		Sender:   client.SenderFunc(common.SendNativeTx),
+	}
+}
+
+//Result of a method
+type Result struct {
+	//Hash of transactions sent
+	TxHashes []string
+	//Query outputs
+	Outputs []*Output
+	//Warnings which do not fail the method
+	Warnings []string
+}
+
+//Output is a named query output
+type Output struct {
+	Name  string
+	Value interface{}
+}
+
+//NewResult return an empty Result
+func NewResult() *Result {
+	return &Result{
+		TxHashes: make([]string, 0),
+		Outputs:  make([]*Output, 0),
+		Warnings: make([]string, 0),
+	}
+}
+
+//AddTxHash record a transaction sent by method
+func (this *Result) AddTxHash(txHash ontcommon.Uint256) {
+	this.TxHashes = append(this.TxHashes, txHash.ToHexString())
+}
+
+//AddOutput record a query output
+func (this *Result) AddOutput(name string, value interface{}) {
+	this.Outputs = append(this.Outputs, &Output{Name: name, Value: value})
+}
+
+//AddWarning record a warning
+func (this *Result) AddWarning(warning string) {
+	this.Warnings = append(this.Warnings, warning)
+}
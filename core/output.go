@@ -0,0 +1,438 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//Units of amounts in outputs
+const (
+	UNIT_ONT = "ONT"
+	UNIT_ONG = "ONG"
+)
+
+//ONG_DECIMALS is the decimals of ONG, ONT has none
+const ONG_DECIMALS = 9
+
+//amountUnits is the unit of output fields and scalar outputs holding amounts, which are rendered in raw units
+//and again in ONT or ONG
+var amountUnits = map[string]string{
+	"InitPos":              UNIT_ONT,
+	"TotalPos":             UNIT_ONT,
+	"ConsensusPos":         UNIT_ONT,
+	"CandidatePos":         UNIT_ONT,
+	"NewPos":               UNIT_ONT,
+	"WithdrawConsensusPos": UNIT_ONT,
+	"WithdrawCandidatePos": UNIT_ONT,
+	"WithdrawUnfreezePos":  UNIT_ONT,
+	"Stake":                UNIT_ONT,
+	"AuthorizePos":         UNIT_ONT,
+	"PromisePos":           UNIT_ONT,
+	"MaxAuthorize":         UNIT_ONT,
+	"MinInitStake":         UNIT_ONT,
+	"MinAuthorizePos":      UNIT_ONT,
+	"CandidateFee":         UNIT_ONG,
+	"Amount":               UNIT_ONG,
+	"Ong":                  UNIT_ONG,
+	"AnnualOng":            UNIT_ONG,
+	"CollectedOng":         UNIT_ONG,
+	"IncomeOng":            UNIT_ONG,
+	"DappOng":              UNIT_ONG,
+	"ConsensusOng":         UNIT_ONG,
+	"CandidateOng":         UNIT_ONG,
+	"NodeOng":              UNIT_ONG,
+	"OwnerOng":             UNIT_ONG,
+	"AuthorizeOng":         UNIT_ONG,
+	"splitFee":             UNIT_ONG,
+}
+
+//FormatAmount return raw units of unit as a decimal ONT or ONG amount
+func FormatAmount(raw uint64, unit string) string {
+	if unit != UNIT_ONG {
+		return fmt.Sprintf("%d", raw)
+	}
+	base := uint64(1000000000)
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", ONG_DECIMALS, raw%base), "0")
+	if fraction == "" {
+		return fmt.Sprintf("%d", raw/base)
+	}
+	return fmt.Sprintf("%d.%s", raw/base, fraction)
+}
+
+//outputTable is an output flattened to rows of columns. A struct is one row, a map or a slice is a row per
+//element, and so is a struct of a single map or slice field such as PeerPoolMap
+type outputTable struct {
+	name    string
+	columns []string
+	rows    []map[string]interface{}
+	//single is true if the output is one value, not a collection
+	single bool
+}
+
+func newOutputTable(output *Output) *outputTable {
+	table := &outputTable{name: output.Name}
+	v := indirect(reflect.ValueOf(output.Value))
+	elemName := "Value"
+	if v.IsValid() && v.Kind() == reflect.Struct && !isLeaf(v) {
+		if field, name, ok := singleCollection(v); ok {
+			v, elemName = field, name
+		}
+	}
+	switch {
+	case !v.IsValid():
+		table.single = true
+		table.addRow(nil, output.Name, v)
+	case v.Kind() == reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return lessElem(v.MapIndex(keys[i]), v.MapIndex(keys[j]), keys[i], keys[j])
+		})
+		for _, key := range keys {
+			table.addRow([]interface{}{"Key", fmt.Sprint(key.Interface())}, elemName, v.MapIndex(key))
+		}
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8:
+		for i := 0; i < v.Len(); i++ {
+			table.addRow([]interface{}{"Index", i}, elemName, v.Index(i))
+		}
+	default:
+		table.single = true
+		table.addRow(nil, output.Name, v)
+	}
+	return table
+}
+
+//addRow add a row of elem, prefixed by the key column of a map or the index of a slice if elem is not a struct
+func (this *outputTable) addRow(key []interface{}, name string, elem reflect.Value) {
+	row := make(map[string]interface{})
+	elem = indirect(elem)
+	if elem.IsValid() && elem.Kind() == reflect.Struct && !isLeaf(elem) {
+		this.addStruct(row, "", elem)
+	} else {
+		if key != nil {
+			this.addCell(row, key[0].(string), key[1])
+		}
+		this.addValue(row, name, elem)
+	}
+	this.rows = append(this.rows, row)
+}
+
+func (this *outputTable) addStruct(row map[string]interface{}, prefix string, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		this.addValue(row, prefix+v.Type().Field(i).Name, indirect(v.Field(i)))
+	}
+}
+
+//addValue add the cells of a field, and the amount in ONT or ONG if it is an amount
+func (this *outputTable) addValue(row map[string]interface{}, name string, v reflect.Value) {
+	if v.IsValid() && v.Kind() == reflect.Struct && !isLeaf(v) {
+		this.addStruct(row, name+".", v)
+		return
+	}
+	cell := leafValue(v)
+	this.addCell(row, name, cell)
+	field := name[strings.LastIndex(name, ".")+1:]
+	if unit, ok := amountUnits[field]; ok {
+		if raw, ok := toUint64(v); ok {
+			this.addCell(row, fmt.Sprintf("%s (%s)", name, unit), FormatAmount(raw, unit))
+		}
+	}
+}
+
+func (this *outputTable) addCell(row map[string]interface{}, column string, value interface{}) {
+	if _, ok := row[column]; !ok {
+		found := false
+		for _, c := range this.columns {
+			if c == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			this.columns = append(this.columns, column)
+		}
+	}
+	row[column] = value
+}
+
+//indirect dereference pointers and interfaces, invalid if nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+//isLeaf return true if v is rendered as one cell: addresses, bytes, durations and scalars
+func isLeaf(v reflect.Value) bool {
+	switch v.Type() {
+	case reflect.TypeOf(ontcommon.Address{}), reflect.TypeOf(time.Duration(0)), reflect.TypeOf(time.Time{}):
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return false
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return true
+		}
+		elem := v.Type().Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		return elem.Kind() != reflect.Struct && elem.Kind() != reflect.Map
+	}
+	return true
+}
+
+//singleCollection return the only exported field of struct v if it is a map or a slice of values
+func singleCollection(v reflect.Value) (reflect.Value, string, bool) {
+	index := -1
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		if index >= 0 {
+			return reflect.Value{}, "", false
+		}
+		index = i
+	}
+	if index < 0 {
+		return reflect.Value{}, "", false
+	}
+	field := v.Field(index)
+	if field.Kind() == reflect.Map || (field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8) {
+		return field, v.Type().Field(index).Name, true
+	}
+	return reflect.Value{}, "", false
+}
+
+//lessElem order map elements by their Index field if they have one, by key otherwise
+func lessElem(a, b, keyA, keyB reflect.Value) bool {
+	a, b = indirect(a), indirect(b)
+	if a.IsValid() && b.IsValid() && a.Kind() == reflect.Struct && b.Kind() == reflect.Struct {
+		indexA, indexB := a.FieldByName("Index"), b.FieldByName("Index")
+		if x, ok := toUint64(indexA); ok {
+			if y, ok := toUint64(indexB); ok && x != y {
+				return x < y
+			}
+		}
+	}
+	return fmt.Sprint(keyA.Interface()) < fmt.Sprint(keyB.Interface())
+}
+
+//leafValue return v as a json value: numbers, bools and strings as they are, addresses in base58, bytes in
+//hex, and other values as text
+func leafValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	switch value := v.Interface().(type) {
+	case ontcommon.Address:
+		return value.ToBase58()
+	case time.Duration:
+		return value.String()
+	case time.Time:
+		return value.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return v.Interface()
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			return hex.EncodeToString(data)
+		}
+		if isLeaf(v) {
+			values := make([]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				values = append(values, fmt.Sprint(leafValue(indirect(v.Index(i)))))
+			}
+			return strings.Join(values, " ")
+		}
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(data)
+}
+
+func toUint64(v reflect.Value) (uint64, bool) {
+	if !v.IsValid() {
+		return 0, false
+	}
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	}
+	return 0, false
+}
+
+//formatCell return a cell as text of table and csv
+func formatCell(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+//RenderOutputs write outputs of a method in format, OUTPUT_TABLE, OUTPUT_JSON or OUTPUT_CSV
+func RenderOutputs(w io.Writer, format string, outputs []*Output) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+	tables := make([]*outputTable, 0, len(outputs))
+	for _, output := range outputs {
+		tables = append(tables, newOutputTable(output))
+	}
+	switch format {
+	case config.OUTPUT_TABLE, "":
+		return renderTable(w, tables)
+	case config.OUTPUT_JSON:
+		return renderJson(w, tables)
+	case config.OUTPUT_CSV:
+		return renderCsv(w, tables)
+	}
+	return fmt.Errorf("unknown output format %s, should be %s, %s or %s", format, config.OUTPUT_TABLE,
+		config.OUTPUT_JSON, config.OUTPUT_CSV)
+}
+
+//renderTable write a single value as aligned name and value lines, and a collection as aligned columns
+func renderTable(w io.Writer, tables []*outputTable) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, table := range tables {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		if table.single {
+			for _, column := range table.columns {
+				fmt.Fprintf(tw, "%s\t%s\n", column, formatCell(table.rows[0][column]))
+			}
+			continue
+		}
+		fmt.Fprintf(tw, "%s: %d\n", table.name, len(table.rows))
+		fmt.Fprintln(tw, strings.Join(table.columns, "\t"))
+		for _, row := range table.rows {
+			cells := make([]string, 0, len(table.columns))
+			for _, column := range table.columns {
+				cells = append(cells, formatCell(row[column]))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+//renderJson write an object of outputs by name, a single value is an object and a collection an array
+func renderJson(w io.Writer, tables []*outputTable) error {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{")
+	for i, table := range tables {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, _ := json.Marshal(table.name)
+		buf.Write(name)
+		buf.WriteString(":")
+		if !table.single {
+			buf.WriteString("[")
+		}
+		for j, row := range table.rows {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("{")
+			for k, column := range table.columns {
+				if k > 0 {
+					buf.WriteString(",")
+				}
+				key, _ := json.Marshal(column)
+				value, err := json.Marshal(row[column])
+				if err != nil {
+					return fmt.Errorf("json.Marshal %s error:%s", column, err)
+				}
+				buf.Write(key)
+				buf.WriteString(":")
+				buf.Write(value)
+			}
+			buf.WriteString("}")
+		}
+		if !table.single {
+			buf.WriteString("]")
+		}
+	}
+	buf.WriteString("}")
+	out := &bytes.Buffer{}
+	err := json.Indent(out, buf.Bytes(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Indent error:%s", err)
+	}
+	out.WriteString("\n")
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+//renderCsv write a header and rows of each output, outputs are separated by an empty line
+func renderCsv(w io.Writer, tables []*outputTable) error {
+	cw := csv.NewWriter(w)
+	for i, table := range tables {
+		if i > 0 {
+			cw.Flush()
+			fmt.Fprintln(w)
+		}
+		err := cw.Write(table.columns)
+		if err != nil {
+			return fmt.Errorf("write csv error:%s", err)
+		}
+		for _, row := range table.rows {
+			cells := make([]string, 0, len(table.columns))
+			for _, column := range table.columns {
+				cells = append(cells, formatCell(row[column]))
+			}
+			err = cw.Write(cells)
+			if err != nil {
+				return fmt.Errorf("write csv error:%s", err)
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+//ParamSource is where a method reads its params from, resolved by OntologyTool before method start
+type ParamSource struct {
+	//Inline params
+	Data json.RawMessage
+	//Params file, used if Data is empty
+	Path string
+}
+
+//Load unmarshal params into v
+func (this *ParamSource) Load(v interface{}) error {
+	data, err := this.Read()
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal params of %s error:%s", this, err)
+	}
+	return nil
+}
+
+//Read return raw params
+func (this *ParamSource) Read() ([]byte, error) {
+	if len(this.Data) > 0 {
+		return this.Data, nil
+	}
+	data, err := ioutil.ReadFile(this.Path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile %s error:%s", this.Path, err)
+	}
+	return data, nil
+}
+
+func (this *ParamSource) String() string {
+	if len(this.Data) > 0 {
+		return "inline params"
+	}
+	return this.Path
+}
+
+func hasInlineParams(data json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(data))
+	return trimmed != "" && trimmed != "null"
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ontio/ontology-tool/config"
+)
+
+//MAX_SUGGESTIONS is the max number of suggested names for an unknown method
+const MAX_SUGGESTIONS = 3
+
+//MethodInfo describe a registered method
+type MethodInfo struct {
+	Name        string
+	Method      Method
+	Description string
+	//Params is a pointer to the params struct of method, nil if method takes no params
+	Params interface{}
+	//SendTx is true if method sends transactions, false if method is read-only
+	SendTx bool
+	//MultiSign is true if transactions are signed by a multi-sign address
+	MultiSign bool
+}
+
+//Kind return "query", "tx" or "multisign tx"
+func (this *MethodInfo) Kind() string {
+	if !this.SendTx {
+		return "query"
+	}
+	if this.MultiSign {
+		return "multisign tx"
+	}
+	return "tx"
+}
+
+//ParamsType return name of params struct, "-" if method takes no params
+func (this *MethodInfo) ParamsType() string {
+	if this.Params == nil {
+		return "-"
+	}
+	return paramsStructType(this.Params).Name()
+}
+
+//ListMethods print the catalog of registered methods
+func (this *OntologyTool) ListMethods(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tKIND\tPARAMS\tDESCRIPTION")
+	for _, name := range this.methodNames() {
+		info := this.methodsMap[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Name, info.Kind(), info.ParamsType(), info.Description)
+	}
+	tw.Flush()
+}
+
+//describeFields print a line per exported field of typ. Fields of embedded structs are params of their own, as
+//json decodes them
+func describeFields(w io.Writer, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			describeFields(w, field.Type)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\n", field.Name, field.Type)
+	}
+}
+
+//DescribeMethod print the params schema of a method with an example params json
+func (this *OntologyTool) DescribeMethod(w io.Writer, name string) error {
+	info := this.getMethodByName(name)
+	if info == nil {
+		return this.unknownMethodError(name)
+	}
+	fmt.Fprintf(w, "Method:      %s\n", info.Name)
+	fmt.Fprintf(w, "Description: %s\n", info.Description)
+	fmt.Fprintf(w, "Kind:        %s\n", info.Kind())
+	fmt.Fprintf(w, "Params:      %s\n", info.ParamsType())
+	if info.Params == nil {
+		return nil
+	}
+	typ := paramsStructType(info.Params)
+	fmt.Fprintln(w, "")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tTYPE")
+	describeFields(tw, typ)
+	tw.Flush()
+	data, err := json.MarshalIndent(exampleValue(typ).Interface(), "", "   ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent error:%s", err)
+	}
+	fmt.Fprintf(w, "\nExample %s.json:\n%s\n", info.Name, data)
+	return nil
+}
+
+//CheckSteps return error if any step refers to an unregistered method, to a method not allowed on the
+//network, or to a method which does not send multi-sign transactions when exporting
+func (this *OntologyTool) CheckSteps(steps []*Step) error {
+	unknown := make([]string, 0)
+	for i, step := range steps {
+		for _, problem := range this.checkStep(step) {
+			unknown = append(unknown, fmt.Sprintf("step %d: %s", i+1, problem))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%s", strings.Join(unknown, "; "))
+	}
+	return nil
+}
+
+//checkStep return why CheckSteps rejects step, empty if it does not
+func (this *OntologyTool) checkStep(step *Step) []string {
+	problems := make([]string, 0)
+	info := this.getMethodByName(step.Method)
+	if info == nil {
+		return append(problems, this.unknownMethodError(step.Method).Error())
+	}
+	if !config.DefConfig.MethodAllowed(step.Method) {
+		problems = append(problems, fmt.Sprintf("method %s not allowed on network %s", step.Method, config.DefConfig.NetworkName()))
+	}
+	if config.DefConfig.Export != "" && !info.MultiSign {
+		problems = append(problems, fmt.Sprintf("method %s does not send multi-sign tx, can not be exported", step.Method))
+	}
+	return problems
+}
+
+func (this *OntologyTool) unknownMethodError(name string) error {
+	suggestions := this.suggestMethods(name)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("method %s not registered", name)
+	}
+	return fmt.Errorf("method %s not registered, did you mean %s?", name, strings.Join(suggestions, ", "))
+}
+
+//suggestMethods return registered names closest to name
+func (this *OntologyTool) suggestMethods(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	lower := strings.ToLower(name)
+	maxDistance := len(name)/3 + 1
+	candidates := make([]*candidate, 0)
+	for _, registered := range this.methodNames() {
+		regLower := strings.ToLower(registered)
+		distance := editDistance(lower, regLower)
+		if distance > maxDistance && !strings.Contains(regLower, lower) {
+			continue
+		}
+		candidates = append(candidates, &candidate{name: registered, distance: distance})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	suggestions := make([]string, 0, MAX_SUGGESTIONS)
+	for i := 0; i < len(candidates) && i < MAX_SUGGESTIONS; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+func (this *OntologyTool) methodNames() []string {
+	names := make([]string, 0, len(this.methodsMap))
+	for name := range this.methodsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//editDistance return the levenshtein distance of a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func paramsStructType(params interface{}) reflect.Type {
+	typ := reflect.TypeOf(params)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+//exampleValue return a value of typ with one element in every slice, so the example shows the element type
+func exampleValue(typ reflect.Type) reflect.Value {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		value := reflect.New(typ.Elem())
+		value.Elem().Set(exampleValue(typ.Elem()))
+		return value
+	case reflect.Slice:
+		value := reflect.MakeSlice(typ, 0, 1)
+		return reflect.Append(value, exampleValue(typ.Elem()))
+	case reflect.Struct:
+		value := reflect.New(typ).Elem()
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).PkgPath != "" {
+				continue
+			}
+			value.Field(i).Set(exampleValue(typ.Field(i).Type))
+		}
+		return value
+	default:
+		return reflect.Zero(typ)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+type jsonReport struct {
+	Total    int
+	Success  int
+	Failed   int
+	Skip     int
+	Duration float64
+	Methods  []*jsonMethodResult
+}
+
+type jsonMethodResult struct {
+	Index    int
+	Name     string
+	Label    string `json:",omitempty"`
+	Status   string
+	Start    time.Time
+	Duration float64
+	TxHashes []string
+	Outputs  []*Output `json:",omitempty"`
+	Warnings []string  `json:",omitempty"`
+	Error    string    `json:",omitempty"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+//writeReport write <prefix>.json and <prefix>.xml(JUnit) of method results
+func writeReport(prefix string, results []*MethodResult) error {
+	data, err := json.MarshalIndent(newJsonReport(results), "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent error:%s", err)
+	}
+	err = ioutil.WriteFile(prefix+".json", data, 0644)
+	if err != nil {
+		return fmt.Errorf("WriteFile %s.json error:%s", prefix, err)
+	}
+	data, err = xml.MarshalIndent(newJunitReport(results), "", "  ")
+	if err != nil {
+		return fmt.Errorf("xml.MarshalIndent error:%s", err)
+	}
+	err = ioutil.WriteFile(prefix+".xml", append([]byte(xml.Header), data...), 0644)
+	if err != nil {
+		return fmt.Errorf("WriteFile %s.xml error:%s", prefix, err)
+	}
+	return nil
+}
+
+func newJsonReport(results []*MethodResult) *jsonReport {
+	report := &jsonReport{
+		Total:   len(results),
+		Methods: make([]*jsonMethodResult, 0, len(results)),
+	}
+	for _, res := range results {
+		switch res.Status {
+		case STATUS_PASS:
+			report.Success++
+		case STATUS_FAIL:
+			report.Failed++
+		default:
+			report.Skip++
+		}
+		report.Duration += res.Duration.Seconds()
+		report.Methods = append(report.Methods, &jsonMethodResult{
+			Index:    res.Index,
+			Name:     res.Name,
+			Label:    res.Label,
+			Status:   res.Status,
+			Start:    res.Start,
+			Duration: res.Duration.Seconds(),
+			TxHashes: res.TxHashes,
+			Outputs:  res.Outputs,
+			Warnings: res.Warnings,
+			Error:    res.Error,
+		})
+	}
+	return report
+}
+
+func newJunitReport(results []*MethodResult) *junitTestSuites {
+	suite := &junitTestSuite{
+		Name:      "ontology-tool",
+		Tests:     len(results),
+		TestCases: make([]*junitTestCase, 0, len(results)),
+	}
+	var total time.Duration
+	for i, res := range results {
+		if i == 0 {
+			suite.Timestamp = res.Start.Format("2006-01-02T15:04:05")
+		}
+		total += res.Duration
+		testCase := &junitTestCase{
+			Name:      fmt.Sprintf("%d.%s", res.Index, res.displayName()),
+			ClassName: "ontology-tool",
+			Time:      formatSeconds(res.Duration),
+		}
+		if len(res.TxHashes) > 0 {
+			testCase.SystemOut = "TxHashes:\n" + strings.Join(res.TxHashes, "\n")
+		}
+		switch res.Status {
+		case STATUS_PASS:
+		case STATUS_FAIL:
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: res.Error}
+		default:
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: res.Error}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Time = formatSeconds(total)
+	return &junitTestSuites{TestSuites: []*junitTestSuite{suite}}
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
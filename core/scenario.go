@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+//METHOD_PARAMS_SEP split method name and params file in cmdline, e.g. RegisterCandidate@path/to/params.json
+const METHOD_PARAMS_SEP = "@"
+
+//Scenario is a list of steps run in order, loaded from a scenario file
+type Scenario struct {
+	//Name of scenario
+	Name string
+	//Steps to run
+	Steps []*Step
+}
+
+//Step is one method invocation of a scenario
+type Step struct {
+	//Method name registered in OntologyTool
+	Method string
+	//Label of step, used in log and report instead of method name if not empty
+	Label string
+	//Inline params of method
+	Params json.RawMessage
+	//Params file of method, relative to the scenario file. Ignored if Params is not empty
+	ParamsFile string
+	//Wait policy after method finish
+	Wait *WaitPolicy
+	//Run next step even if this step failed
+	ContinueOnError bool
+}
+
+//WaitPolicy defines how long to wait after a step finish
+type WaitPolicy struct {
+	//Number of new blocks to wait for
+	Blocks uint32
+	//Timeout in seconds of waiting blocks, default 30 seconds per block
+	Timeout uint32
+	//Seconds to sleep
+	Seconds uint32
+}
+
+//NewMethodSteps return steps of methods list in cmdline. A method may be followed by its params file as Method@path
+func NewMethodSteps(methodsList []string) []*Step {
+	steps := make([]*Step, 0, len(methodsList))
+	for _, method := range methodsList {
+		step := &Step{
+			Method:          method,
+			ContinueOnError: true,
+		}
+		if index := strings.Index(method, METHOD_PARAMS_SEP); index >= 0 {
+			step.Method = method[:index]
+			step.ParamsFile = method[index+len(METHOD_PARAMS_SEP):]
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+//LoadScenario load a scenario file
+func LoadScenario(fileName string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile %s error:%s", fileName, err)
+	}
+	scenario := new(Scenario)
+	err = json.Unmarshal(data, scenario)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal scenario %s error:%s", fileName, err)
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("scenario %s has no step", fileName)
+	}
+	dir := filepath.Dir(fileName)
+	for i, step := range scenario.Steps {
+		if step.Method == "" {
+			return nil, fmt.Errorf("step %d of scenario %s has no method", i+1, fileName)
+		}
+		if step.ParamsFile != "" && !filepath.IsAbs(step.ParamsFile) {
+			step.ParamsFile = filepath.Join(dir, step.ParamsFile)
+		}
+	}
+	return scenario, nil
+}
+
+//Name return label of step, or method name if label is empty
+func (this *Step) Name() string {
+	if this.Label != "" {
+		return this.Label
+	}
+	return this.Method
+}
+
+//ParamSource return params of step. Inline params take precedence over params file,
+//and <paramsDir>/<Method>.json is used if step set neither
+func (this *Step) ParamSource(paramsDir string) *ParamSource {
+	if hasInlineParams(this.Params) {
+		return &ParamSource{Data: this.Params}
+	}
+	if this.ParamsFile != "" {
+		return &ParamSource{Path: this.ParamsFile}
+	}
+	return &ParamSource{Path: filepath.Join(paramsDir, this.Method+".json")}
+}
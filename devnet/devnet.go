@@ -0,0 +1,355 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+//Package devnet run a single node ontology chain in process: the real ledger and native contracts, a solo
+//block producer, a generated genesis and a local json rpc endpoint, so governance methods can run end to end
+//without an external network
+package devnet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	log4 "github.com/alecthomas/log4go"
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology/account"
+	ontcommon "github.com/ontio/ontology/common"
+	ontcfg "github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/common/log"
+	"github.com/ontio/ontology/consensus"
+	"github.com/ontio/ontology/core/genesis"
+	"github.com/ontio/ontology/core/ledger"
+	"github.com/ontio/ontology/core/signature"
+	"github.com/ontio/ontology/core/types"
+	"github.com/ontio/ontology/events"
+	hserver "github.com/ontio/ontology/http/base/actor"
+	"github.com/ontio/ontology/http/jsonrpc"
+	"github.com/ontio/ontology/txnpool"
+	tc "github.com/ontio/ontology/txnpool/common"
+	tp "github.com/ontio/ontology/txnpool/proc"
+	"github.com/ontio/ontology/validator/stateful"
+	"github.com/ontio/ontology/validator/stateless"
+)
+
+//GENESIS_FILE keep the genesis of a devnet in its data dir, so the chain is opened with the same genesis again
+const GENESIS_FILE = "genesis.json"
+
+//START_TIMEOUT is the time to wait the json rpc endpoint answering
+const START_TIMEOUT = 10 * time.Second
+
+//Devnet is a running single node chain
+type Devnet struct {
+	//JsonRpcAddress of the node
+	JsonRpcAddress string
+	//Genesis of the chain
+	Genesis   *ontcfg.GenesisConfig
+	dataDir   string
+	tempDir   bool
+	txPool    *tp.TXPoolServer
+	consensus consensus.ConsensusService
+}
+
+//Start start a devnet of cfg whose blocks are produced by the first bookkeeper. The json rpc endpoint
+//answers when Start returns. Only one devnet can run in a process. If it fails, what is started is stopped
+func Start(cfg *config.DevnetConfig, bookkeepers []*sdk.Account) (*Devnet, error) {
+	if len(bookkeepers) == 0 {
+		return nil, fmt.Errorf("devnet needs a bookkeeper")
+	}
+	devnet := &Devnet{dataDir: cfg.DataDir}
+	err := devnet.start(cfg, bookkeepers)
+	if err != nil {
+		devnet.Stop()
+		return nil, err
+	}
+	log4.Info("Devnet started at %s, data dir %s, %d peers, blocks every %d seconds", devnet.JsonRpcAddress,
+		devnet.dataDir, len(devnet.Genesis.VBFT.Peers), devnet.Genesis.SOLO.GenBlockTime)
+	return devnet, nil
+}
+
+func (this *Devnet) start(cfg *config.DevnetConfig, bookkeepers []*sdk.Account) error {
+	if this.dataDir == "" {
+		dir, err := ioutil.TempDir("", "ontology-devnet")
+		if err != nil {
+			return fmt.Errorf("TempDir error:%s", err)
+		}
+		this.dataDir = dir
+		this.tempDir = true
+	}
+	genesisConfig, err := loadGenesis(this.dataDir)
+	if err != nil {
+		return err
+	}
+	if genesisConfig == nil {
+		genesisConfig, err = newGenesisConfig(cfg, bookkeepers)
+		if err != nil {
+			return err
+		}
+		err = saveGenesis(this.dataDir, genesisConfig)
+		if err != nil {
+			return err
+		}
+	}
+	producerKey := hex.EncodeToString(keypair.SerializePublicKey(bookkeepers[0].PublicKey))
+	if len(genesisConfig.SOLO.Bookkeepers) == 0 || genesisConfig.SOLO.Bookkeepers[0] != producerKey {
+		return fmt.Errorf("bookkeeper %s is not the producer of devnet genesis", bookkeepers[0].Address.ToBase58())
+	}
+	this.Genesis = genesisConfig
+	port := cfg.JsonRpcPort
+	if port == 0 {
+		port = config.DEFAULT_DEVNET_RPC_PORT
+	}
+	this.JsonRpcAddress = fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	ontcfg.DefConfig.Genesis = genesisConfig
+	ontcfg.DefConfig.Common.DataDir = this.dataDir
+	ontcfg.DefConfig.Common.GasPrice = 0
+	ontcfg.DefConfig.P2PNode.NetworkId = ontcfg.NETWORK_ID_SOLO_NET
+	ontcfg.DefConfig.P2PNode.NetworkName = ontcfg.NETWORK_NAME_SOLO_NET
+	ontcfg.DefConfig.Consensus.EnableConsensus = true
+	ontcfg.DefConfig.Rpc.EnableHttpJsonRpc = true
+	ontcfg.DefConfig.Rpc.HttpJsonPort = port
+	log.InitLog(log.InfoLog, filepath.Join(this.dataDir, "log")+string(os.PathSeparator))
+
+	err = this.initLedger()
+	if err != nil {
+		return err
+	}
+	producer := &account.Account{
+		PrivateKey: bookkeepers[0].PrivateKey,
+		PublicKey:  bookkeepers[0].PublicKey,
+		Address:    bookkeepers[0].Address,
+		SigScheme:  bookkeepers[0].SigScheme,
+	}
+	err = this.fastForward(producer, cfg.StartHeight)
+	if err != nil {
+		return err
+	}
+	err = this.initNode(producer)
+	if err != nil {
+		return err
+	}
+	err = this.waitRpc()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Devnet) initLedger() error {
+	events.Init()
+	var err error
+	ledger.DefLedger, err = ledger.NewLedger(filepath.Join(this.dataDir, ontcfg.NETWORK_NAME_SOLO_NET), 0)
+	if err != nil {
+		return fmt.Errorf("NewLedger error:%s", err)
+	}
+	bookkeepers, err := ontcfg.DefConfig.GetBookkeepers()
+	if err != nil {
+		return fmt.Errorf("GetBookkeepers error:%s", err)
+	}
+	genesisBlock, err := genesis.BuildGenesisBlock(bookkeepers, this.Genesis)
+	if err != nil {
+		return fmt.Errorf("BuildGenesisBlock error:%s", err)
+	}
+	err = ledger.DefLedger.Init(bookkeepers, genesisBlock)
+	if err != nil {
+		return fmt.Errorf("init ledger error:%s", err)
+	}
+	return nil
+}
+
+//fastForward add empty blocks of producer until height, one second apart from the last block
+func (this *Devnet) fastForward(producer *account.Account, height uint32) error {
+	current := ledger.DefLedger.GetCurrentBlockHeight()
+	if current >= height {
+		return nil
+	}
+	log4.Info("Devnet fast forward from height %d to %d", current, height)
+	nextBookkeeper, err := types.AddressFromBookkeepers([]keypair.PublicKey{producer.PublicKey})
+	if err != nil {
+		return fmt.Errorf("AddressFromBookkeepers error:%s", err)
+	}
+	txRoot := ontcommon.ComputeMerkleRoot([]ontcommon.Uint256{})
+	prevHeader, err := ledger.DefLedger.GetHeaderByHeight(current)
+	if err != nil {
+		return fmt.Errorf("GetHeaderByHeight %d error:%s", current, err)
+	}
+	timestamp := prevHeader.Timestamp
+	if end := uint32(time.Now().Unix()) - (height - current); timestamp < end {
+		timestamp = end
+	}
+	for h := current + 1; h <= height; h++ {
+		timestamp++
+		block := &types.Block{
+			Header: &types.Header{
+				PrevBlockHash:    ledger.DefLedger.GetCurrentBlockHash(),
+				TransactionsRoot: txRoot,
+				BlockRoot:        ledger.DefLedger.GetBlockRootWithNewTxRoots(h, []ontcommon.Uint256{txRoot}),
+				Timestamp:        timestamp,
+				Height:           h,
+				ConsensusData:    ontcommon.GetNonce(),
+				NextBookkeeper:   nextBookkeeper,
+			},
+		}
+		blockHash := block.Hash()
+		sig, err := signature.Sign(producer, blockHash[:])
+		if err != nil {
+			return fmt.Errorf("sign block error:%s", err)
+		}
+		block.Header.Bookkeepers = []keypair.PublicKey{producer.PublicKey}
+		block.Header.SigData = [][]byte{sig}
+		result, err := ledger.DefLedger.ExecuteBlock(block)
+		if err != nil {
+			return fmt.Errorf("ExecuteBlock %d error:%s", h, err)
+		}
+		err = ledger.DefLedger.SubmitBlock(block, nil, result)
+		if err != nil {
+			return fmt.Errorf("SubmitBlock %d error:%s", h, err)
+		}
+	}
+	return nil
+}
+
+//initNode start tx pool, solo consensus of producer and json rpc, as the ontology node does
+func (this *Devnet) initNode(producer *account.Account) error {
+	txPoolServer, err := txnpool.StartTxnPoolServer(false, true)
+	if err != nil {
+		return fmt.Errorf("StartTxnPoolServer error:%s", err)
+	}
+	this.txPool = txPoolServer
+	stlValidator, _ := stateless.NewValidator("stateless_validator")
+	stlValidator.Register(txPoolServer.GetPID(tc.VerifyRspActor))
+	stlValidator2, _ := stateless.NewValidator("stateless_validator2")
+	stlValidator2.Register(txPoolServer.GetPID(tc.VerifyRspActor))
+	stfValidator, _ := stateful.NewValidator("stateful_validator")
+	stfValidator.Register(txPoolServer.GetPID(tc.VerifyRspActor))
+	hserver.SetTxnPoolPid(txPoolServer.GetPID(tc.TxPoolActor))
+	hserver.SetTxPid(txPoolServer.GetPID(tc.TxActor))
+
+	this.consensus, err = consensus.NewConsensusService(consensus.CONSENSUS_SOLO, producer, txPoolServer.GetPID(tc.TxPoolActor), nil, nil)
+	if err != nil {
+		return fmt.Errorf("NewConsensusService error:%s", err)
+	}
+	err = this.consensus.Start()
+	if err != nil {
+		return fmt.Errorf("start consensus error:%s", err)
+	}
+	hserver.SetConsensusPid(this.consensus.GetPID())
+
+	rpcErr := make(chan error, 1)
+	go func() {
+		rpcErr <- jsonrpc.StartRPCServer()
+	}()
+	select {
+	case err := <-rpcErr:
+		return fmt.Errorf("StartRPCServer error:%s", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	return nil
+}
+
+//newSdk return a sdk connected to the json rpc endpoint
+func (this *Devnet) newSdk() *sdk.OntologySdk {
+	ontSdk := sdk.NewOntologySdk()
+	ontSdk.NewRpcClient().SetAddress(this.JsonRpcAddress)
+	return ontSdk
+}
+
+//waitRpc wait the json rpc endpoint answering
+func (this *Devnet) waitRpc() error {
+	ontSdk := this.newSdk()
+	deadline := time.Now().Add(START_TIMEOUT)
+	for {
+		_, err := ontSdk.GetCurrentBlockHeight()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("json rpc %s not answering:%s", this.JsonRpcAddress, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+//Stop stop producing blocks, the tx pool and the ledger, if they are started. A temporary data dir is removed
+func (this *Devnet) Stop() {
+	if this.consensus != nil {
+		err := this.consensus.Halt()
+		if err != nil {
+			log4.Error("Halt consensus error:%s", err)
+		}
+		this.consensus = nil
+	}
+	if this.txPool != nil {
+		this.txPool.Stop()
+		this.txPool = nil
+	}
+	if ledger.DefLedger != nil {
+		err := ledger.DefLedger.Close()
+		if err != nil {
+			log4.Error("Close ledger error:%s", err)
+		}
+		ledger.DefLedger = nil
+	}
+	if this.tempDir {
+		err := os.RemoveAll(this.dataDir)
+		if err != nil {
+			log4.Error("RemoveAll %s error:%s", this.dataDir, err)
+		}
+	}
+}
+
+//loadGenesis return the genesis saved in dataDir, nil if not saved
+func loadGenesis(dataDir string) (*ontcfg.GenesisConfig, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dataDir, GENESIS_FILE))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read genesis error:%s", err)
+	}
+	genesisConfig := ontcfg.NewGenesisConfig()
+	err = json.Unmarshal(data, genesisConfig)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal genesis error:%s", err)
+	}
+	log4.Info("Load devnet genesis from %s, devnet config of genesis is ignored", dataDir)
+	return genesisConfig, nil
+}
+
+func saveGenesis(dataDir string, genesisConfig *ontcfg.GenesisConfig) error {
+	data, err := json.MarshalIndent(genesisConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Marshal genesis error:%s", err)
+	}
+	err = os.MkdirAll(dataDir, 0755)
+	if err != nil {
+		return fmt.Errorf("MkdirAll %s error:%s", dataDir, err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dataDir, GENESIS_FILE), data, 0644)
+	if err != nil {
+		return fmt.Errorf("write genesis error:%s", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package devnet
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//freePort return a local port nothing listens on
+func freePort(t *testing.T) uint {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen error:%s", err)
+	}
+	defer listener.Close()
+	return uint(listener.Addr().(*net.TCPAddr).Port)
+}
+
+//TestLifecycle run a candidate and its authorization through RegisterCandidate, ApproveCandidate,
+//ChangeMaxAuthorization, AuthorizeForPeer, CommitDpos, UnAuthorizeForPeer, CommitDpos and Withdraw on a devnet
+//at the height of the new governance version. The genesis peers stake more than the candidate, so it stays a
+//candidate and its withdrawn authorization unfreezes at the next CommitDpos
+func TestLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("devnet fast forwards to the new governance version height")
+	}
+	admin := sdk.NewAccount()
+	owner := sdk.NewAccount()
+	authorizer := sdk.NewAccount()
+	peers := make([]*config.DevnetPeer, 0, 7)
+	for i := 0; i < 7; i++ {
+		peers = append(peers, &config.DevnetPeer{
+			PeerPubkey: hex.EncodeToString(keypair.SerializePublicKey(sdk.NewAccount().PublicKey)),
+			InitPos:    200000,
+		})
+	}
+	peerPubkey := hex.EncodeToString(keypair.SerializePublicKey(sdk.NewAccount().PublicKey))
+	cfg := &config.DevnetConfig{
+		JsonRpcPort: freePort(t),
+		Peers:       peers,
+		StartHeight: governance.NEW_VERSION_BLOCK,
+		Fund: []*config.DevnetFund{
+			{Address: owner.Address.ToBase58(), Ont: 100000},
+			{Address: authorizer.Address.ToBase58(), Ont: 1000},
+		},
+	}
+	devnet, err := Start(cfg, []*sdk.Account{admin})
+	if err != nil {
+		t.Fatalf("Start error:%s", err)
+	}
+	defer devnet.Stop()
+	ctx := context.Background()
+	err = devnet.SetCandidateFee(ctx, []*sdk.Account{admin}, 0)
+	if err != nil {
+		t.Fatalf("SetCandidateFee error:%s", err)
+	}
+	err = devnet.Fund(ctx, []*sdk.Account{admin}, cfg.Fund)
+	if err != nil {
+		t.Fatalf("Fund error:%s", err)
+	}
+
+	ontSdk := devnet.newSdk()
+	gov := client.NewGovernanceClient(ontSdk, &client.Options{GasLimit: client.DEFAULT_GAS_LIMIT})
+	ont := client.NewOntClient(ontSdk, nil)
+	confirm := func(txHash ontcommon.Uint256, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = common.ConfirmTx(ctx, ontSdk, txHash.ToHexString())
+		return err
+	}
+	checkAuthorizeInfo := func(step string, want governance.AuthorizeInfo) {
+		info, err := gov.GetAuthorizeInfo(peerPubkey, authorizer.Address)
+		if err != nil {
+			t.Fatalf("%s: GetAuthorizeInfo error:%s", step, err)
+		}
+		if info.NewPos != want.NewPos || info.CandidatePos != want.CandidatePos ||
+			info.WithdrawCandidatePos != want.WithdrawCandidatePos || info.WithdrawUnfreezePos != want.WithdrawUnfreezePos {
+			t.Fatalf("%s: authorize info %+v, should be %+v", step, info, want)
+		}
+	}
+
+	err = confirm(gov.RegisterCandidate(client.NewAccountSigner(owner), peerPubkey, 100000))
+	if err != nil {
+		t.Fatalf("RegisterCandidate error:%s", err)
+	}
+	//solo network registers candidates at once, as mainnet since self-governance, so there is nothing to approve
+	err = confirm(gov.ApproveCandidate(client.NewAccountSigner(admin), peerPubkey))
+	if err == nil || !strings.Contains(err.Error(), "not RegisterCandidateStatus") {
+		t.Fatalf("ApproveCandidate error %v, should be rejected since the peer is a candidate", err)
+	}
+	item, err := gov.GetPeerPoolItem(peerPubkey)
+	if err != nil {
+		t.Fatalf("GetPeerPoolItem error:%s", err)
+	}
+	if item.Status != governance.CandidateStatus || item.InitPos != 100000 {
+		t.Fatalf("peer status %d init pos %d, should be candidate with 100000", item.Status, item.InitPos)
+	}
+
+	err = confirm(gov.ChangeMaxAuthorization(client.NewAccountSigner(owner), peerPubkey, 1000))
+	if err != nil {
+		t.Fatalf("ChangeMaxAuthorization error:%s", err)
+	}
+	err = confirm(gov.AuthorizeForPeer(client.NewAccountSigner(authorizer), []string{peerPubkey}, []uint32{500}))
+	if err != nil {
+		t.Fatalf("AuthorizeForPeer error:%s", err)
+	}
+	checkAuthorizeInfo("authorized", governance.AuthorizeInfo{NewPos: 500})
+	err = confirm(gov.CommitDpos(client.NewAccountSigner(admin)))
+	if err != nil {
+		t.Fatalf("CommitDpos error:%s", err)
+	}
+	checkAuthorizeInfo("committed", governance.AuthorizeInfo{CandidatePos: 500})
+
+	err = confirm(gov.UnAuthorizeForPeer(client.NewAccountSigner(authorizer), []string{peerPubkey}, []uint32{500}))
+	if err != nil {
+		t.Fatalf("UnAuthorizeForPeer error:%s", err)
+	}
+	checkAuthorizeInfo("unauthorized", governance.AuthorizeInfo{WithdrawCandidatePos: 500})
+	err = confirm(gov.CommitDpos(client.NewAccountSigner(admin)))
+	if err != nil {
+		t.Fatalf("CommitDpos error:%s", err)
+	}
+	checkAuthorizeInfo("unfrozen", governance.AuthorizeInfo{WithdrawUnfreezePos: 500})
+
+	err = confirm(gov.Withdraw(client.NewAccountSigner(authorizer), []string{peerPubkey}, []uint32{500}))
+	if err != nil {
+		t.Fatalf("Withdraw error:%s", err)
+	}
+	balance, err := ont.BalanceOf(authorizer.Address)
+	if err != nil {
+		t.Fatalf("BalanceOf error:%s", err)
+	}
+	if balance != 1000 {
+		t.Fatalf("authorizer balance %d after withdraw, should be 1000", balance)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package devnet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+	"github.com/ontio/ontology/smartcontract/service/neovm"
+)
+
+//Fund transfer ONT of funds from the genesis ONT holder, which is signed by bookkeepers. The solo network
+//never unbinds ONG to ONT holders, so there is no ONG to fund and devnet transactions are sent with gas price 0
+func (this *Devnet) Fund(ctx context.Context, bookkeepers []*sdk.Account, funds []*config.DevnetFund) error {
+	if len(funds) == 0 {
+		return nil
+	}
+	ontSdk := this.newSdk()
+	holder := newHolder(bookkeepers)
+	holderAddress, err := holder.address()
+	if err != nil {
+		return err
+	}
+	transfers := ont.Transfers{}
+	for _, fund := range funds {
+		to, err := fundAddress(ontSdk, fund.Address)
+		if err != nil {
+			return err
+		}
+		transfers.States = append(transfers.States, ont.State{From: holderAddress, To: to, Value: fund.Ont})
+	}
+	return holder.send(ctx, ontSdk, utils.OntContractAddress, ont.TRANSFER_NAME, transfers)
+}
+
+//SetCandidateFee update the candidate fee of global param by the governance admin, which is the genesis ONT
+//holder, if it is not fee. Candidates can not pay the mainnet fee with no ONG on devnet
+func (this *Devnet) SetCandidateFee(ctx context.Context, bookkeepers []*sdk.Account, fee uint64) error {
+	ontSdk := this.newSdk()
+	globalParam, err := client.NewGovernanceClient(ontSdk, nil).GetGlobalParam()
+	if err != nil {
+		return err
+	}
+	if globalParam.CandidateFee == fee {
+		return nil
+	}
+	globalParam.CandidateFee = fee
+	return newHolder(bookkeepers).send(ctx, ontSdk, utils.GovernanceContractAddress, governance.UPDATE_GLOBAL_PARAM, globalParam)
+}
+
+//fundAddress return the address of a base58 address or an account path
+func fundAddress(ontSdk *sdk.OntologySdk, address string) (ontcommon.Address, error) {
+	if addr, err := ontcommon.AddressFromBase58(address); err == nil {
+		return addr, nil
+	}
+	addr, err := common.GetAddressByWallet(ontSdk, address)
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, fmt.Errorf("fund address %s error:%s", address, err)
+	}
+	return addr, nil
+}
+
+//holder is the genesis ONT holder, the bookkeeper or the multi-sign address of bookkeepers
+type holder struct {
+	bookkeepers []*sdk.Account
+	pubKeys     []keypair.PublicKey
+}
+
+func newHolder(bookkeepers []*sdk.Account) *holder {
+	pubKeys := make([]keypair.PublicKey, 0, len(bookkeepers))
+	for _, bookkeeper := range bookkeepers {
+		pubKeys = append(pubKeys, bookkeeper.PublicKey)
+	}
+	return &holder{bookkeepers: bookkeepers, pubKeys: pubKeys}
+}
+
+func (this *holder) address() (ontcommon.Address, error) {
+	_, address, err := common.MultiSignAddress(this.pubKeys, 0, "")
+	return address, err
+}
+
+//send a transaction of the holder and wait it confirmed. It is not affected by dry run or export
+func (this *holder) send(ctx context.Context, ontSdk *sdk.OntologySdk, contract ontcommon.Address, method string, param interface{}) error {
+	nativeTx := &common.NativeTx{
+		GasLimit: neovm.MIN_TRANSACTION_GAS,
+		Contract: contract,
+		Method:   method,
+		Params:   []interface{}{param},
+	}
+	if len(this.bookkeepers) == 1 {
+		nativeTx.Signers = this.bookkeepers
+	} else {
+		nativeTx.PubKeys = this.pubKeys
+		nativeTx.MultiSigners = this.bookkeepers[:nativeTx.MultiSignM()]
+	}
+	tx, err := nativeTx.Build()
+	if err != nil {
+		return err
+	}
+	txHash, err := ontSdk.SendTransaction(tx)
+	if err != nil {
+		return fmt.Errorf("devnet %s %s error:%s", common.ContractName(contract), method, err)
+	}
+	_, err = common.ConfirmTx(ctx, ontSdk, txHash.ToHexString())
+	if err != nil {
+		return fmt.Errorf("devnet %s %s error:%s", common.ContractName(contract), method, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package devnet
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/config"
+	ontcfg "github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//DEFAULT_PEER_NUM is the number of peers generated if none is configured, the least K of genesis
+const DEFAULT_PEER_NUM = 7
+
+//newGenesisConfig return a solo genesis of bookkeepers, whose governance config takes the consensus peers
+//and vbft params of cfg, and mainnet genesis values for the others
+func newGenesisConfig(cfg *config.DevnetConfig, bookkeepers []*sdk.Account) (*ontcfg.GenesisConfig, error) {
+	vbft := *ontcfg.MainNetConfig.VBFT
+	if cfg.N != 0 {
+		vbft.N = cfg.N
+	}
+	if cfg.C != 0 {
+		vbft.C = cfg.C
+	}
+	if cfg.L != 0 {
+		vbft.L = cfg.L
+	}
+	if cfg.MaxBlockChangeView != 0 {
+		vbft.MaxBlockChangeView = cfg.MaxBlockChangeView
+	}
+	if cfg.MinInitStake != 0 {
+		vbft.MinInitStake = cfg.MinInitStake
+	}
+	owner := bookkeepers[0].Address.ToBase58()
+	vbft.AdminOntID = client.OntId(bookkeepers[0].Address)
+	peers, err := newPeers(cfg.Peers, owner, uint64(vbft.MinInitStake))
+	if err != nil {
+		return nil, err
+	}
+	vbft.Peers = peers
+	vbft.K = uint32(len(peers))
+	if vbft.N < vbft.K {
+		vbft.N = vbft.K
+	}
+	if cfg.L == 0 {
+		vbft.L = 16 * vbft.K
+	}
+	err = governance.CheckVBFTConfig(&vbft)
+	if err != nil {
+		return nil, fmt.Errorf("genesis vbft config error:%s", err)
+	}
+
+	solo := &ontcfg.SOLOConfig{
+		GenBlockTime: cfg.GenBlockTime,
+	}
+	if solo.GenBlockTime == 0 {
+		solo.GenBlockTime = config.DEFAULT_DEVNET_BLOCK_TIME
+	}
+	for _, bookkeeper := range bookkeepers {
+		solo.Bookkeepers = append(solo.Bookkeepers, hex.EncodeToString(keypair.SerializePublicKey(bookkeeper.PublicKey)))
+	}
+	return &ontcfg.GenesisConfig{
+		SeedList:      []string{},
+		ConsensusType: ontcfg.CONSENSUS_TYPE_SOLO,
+		VBFT:          &vbft,
+		DBFT:          &ontcfg.DBFTConfig{},
+		SOLO:          solo,
+	}, nil
+}
+
+//newPeers return the configured peers with defaults filled, or DEFAULT_PEER_NUM generated ones
+func newPeers(configured []*config.DevnetPeer, owner string, initPos uint64) ([]*ontcfg.VBFTPeerStakeInfo, error) {
+	peers := make([]*ontcfg.VBFTPeerStakeInfo, 0, len(configured))
+	for i, peer := range configured {
+		info := &ontcfg.VBFTPeerStakeInfo{
+			Index:      uint32(i + 1),
+			PeerPubkey: peer.PeerPubkey,
+			Address:    peer.Address,
+			InitPos:    peer.InitPos,
+		}
+		if info.Address == "" {
+			info.Address = owner
+		}
+		if info.InitPos == 0 {
+			info.InitPos = initPos
+		}
+		peers = append(peers, info)
+	}
+	if len(configured) > 0 {
+		return peers, nil
+	}
+	for i := 0; i < DEFAULT_PEER_NUM; i++ {
+		_, pubKey, err := keypair.GenerateKeyPair(keypair.PK_ECDSA, keypair.P256)
+		if err != nil {
+			return nil, fmt.Errorf("GenerateKeyPair error:%s", err)
+		}
+		peers = append(peers, &ontcfg.VBFTPeerStakeInfo{
+			Index:      uint32(i + 1),
+			PeerPubkey: hex.EncodeToString(keypair.SerializePublicKey(pubKey)),
+			Address:    owner,
+			InitPos:    initPos,
+		})
+	}
+	return peers, nil
+}
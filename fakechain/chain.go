@@ -0,0 +1,343 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+//Package fakechain is an in-memory ontology chain implementing common.Chain, to run the client package and
+//the methods of the tool without a node. Storage is filled by Put or the governance setters, pre-execution
+//always succeeds unless a method is set to fail, and every sent transaction is recorded and included in a new
+//block at once
+package fakechain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+	vconfig "github.com/ontio/ontology/consensus/vbft/config"
+	"github.com/ontio/ontology/core/payload"
+	"github.com/ontio/ontology/core/types"
+	cutils "github.com/ontio/ontology/core/utils"
+	"github.com/ontio/ontology/smartcontract/service/native/ont"
+)
+
+//DEFAULT_GAS is the gas of pre-execution and consumed by every sent transaction
+const DEFAULT_GAS = 20000
+
+//SentTx is a transaction sent to the fake chain
+type SentTx struct {
+	Tx *types.MutableTransaction
+	//Contract and Method of a native invocation, empty for other transactions
+	Contract ontcommon.Address
+	Method   string
+	//Height of the block including it
+	Height uint32
+}
+
+//Chain is an in-memory ontology chain
+type Chain struct {
+	lock sync.Mutex
+	//storage map contract address and key to value
+	storage map[string][]byte
+	blocks  []*types.Block
+	sent    []*SentTx
+	//txs map tx hash to sent tx
+	txs map[string]*SentTx
+	//lastConfigBlock is the height of the last block with a new chain config
+	lastConfigBlock uint32
+	//balances map ONT or ONG contract address and address to balance, answered to balanceOf
+	balances map[ontcommon.Address]map[ontcommon.Address]uint64
+	//failures map method to the error returned by its pre-execution
+	failures map[string]error
+	//Gas of pre-execution and consumed by sent transactions
+	Gas uint64
+}
+
+var _ common.Chain = (*Chain)(nil)
+
+//New return a chain with the genesis block only, which carries an empty chain config
+func New() *Chain {
+	chain := &Chain{
+		storage:  make(map[string][]byte),
+		txs:      make(map[string]*SentTx),
+		balances: make(map[ontcommon.Address]map[ontcommon.Address]uint64),
+		failures: make(map[string]error),
+		Gas:      DEFAULT_GAS,
+	}
+	chain.addBlock(&vconfig.ChainConfig{}, nil)
+	return chain
+}
+
+//Put set the storage value of key in contract, an empty value delete it
+func (this *Chain) Put(contract ontcommon.Address, key, value []byte) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if len(value) == 0 {
+		delete(this.storage, storageKey(contract, key))
+		return
+	}
+	this.storage[storageKey(contract, key)] = value
+}
+
+//SetBalance set the balance of address in the ONT or ONG contract, answered to balanceOf pre-executions
+func (this *Chain) SetBalance(contract, address ontcommon.Address, balance uint64) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.balances[contract] == nil {
+		this.balances[contract] = make(map[ontcommon.Address]uint64)
+	}
+	this.balances[contract][address] = balance
+}
+
+//SetFailure make pre-execution of method fail with err, nil err clear it
+func (this *Chain) SetFailure(method string, err error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if err == nil {
+		delete(this.failures, method)
+		return
+	}
+	this.failures[method] = err
+}
+
+//Sent return transactions sent to the chain, in send order
+func (this *Chain) Sent() []*SentTx {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return append([]*SentTx{}, this.sent...)
+}
+
+//AddBlock add an empty block and return its height
+func (this *Chain) AddBlock() uint32 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.addBlock(nil, nil)
+}
+
+//AddConfigBlock add an empty block carrying a new chain config and return its height
+func (this *Chain) AddConfigBlock(config *vconfig.ChainConfig) uint32 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.addBlock(config, nil)
+}
+
+func (this *Chain) addBlock(config *vconfig.ChainConfig, txs []*types.Transaction) uint32 {
+	height := uint32(len(this.blocks))
+	if config != nil {
+		this.lastConfigBlock = height
+	}
+	info, _ := json.Marshal(&vconfig.VbftBlockInfo{
+		LastConfigBlockNum: this.lastConfigBlock,
+		NewChainConfig:     config,
+	})
+	header := &types.Header{
+		Height:           height,
+		Timestamp:        uint32(time.Now().Unix()),
+		ConsensusPayload: info,
+	}
+	if height > 0 {
+		header.PrevBlockHash = this.blocks[height-1].Hash()
+	}
+	block := &types.Block{
+		Header:       header,
+		Transactions: txs,
+	}
+	block.RebuildMerkleRoot()
+	this.blocks = append(this.blocks, block)
+	return height
+}
+
+//GetCurrentBlockHeight return height of the last block
+func (this *Chain) GetCurrentBlockHeight() (uint32, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return uint32(len(this.blocks) - 1), nil
+}
+
+func (this *Chain) GetBlockByHeight(height uint32) (*types.Block, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if height >= uint32(len(this.blocks)) {
+		return nil, fmt.Errorf("unknown block %d", height)
+	}
+	return this.blocks[height], nil
+}
+
+func (this *Chain) GetBlockHash(height uint32) (ontcommon.Uint256, error) {
+	block, err := this.GetBlockByHeight(height)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, err
+	}
+	return block.Hash(), nil
+}
+
+//GetStorage return the value of key in contract, empty if not found
+func (this *Chain) GetStorage(contractAddress string, key []byte) ([]byte, error) {
+	contract, err := ontcommon.AddressFromHexString(contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract address %s:%s", contractAddress, err)
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.storage[storageKey(contract, key)], nil
+}
+
+//PreExecTransaction succeed with Gas unless the method is set to fail. balanceOf of ONT and ONG return
+//the balance set by SetBalance
+func (this *Chain) PreExecTransaction(tx *types.MutableTransaction) (*sdkcom.PreExecResult, error) {
+	contract, method, code := nativeInvocation(tx)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if err := this.failures[method]; err != nil {
+		return nil, err
+	}
+	result := ""
+	if method == ont.BALANCEOF_NAME && len(code) > ontcommon.ADDR_LEN {
+		//the address is the only param
+		address, err := ontcommon.AddressParseFromBytes(code[len(code)-ontcommon.ADDR_LEN:])
+		if err == nil {
+			result = hex.EncodeToString(ontcommon.BigIntToNeoBytes(new(big.Int).SetUint64(this.balances[contract][address])))
+		}
+	}
+	return preExecResult(this.Gas, result)
+}
+
+//SendTransaction record tx and include it in a new block. The same transaction can not be sent twice
+func (this *Chain) SendTransaction(tx *types.MutableTransaction) (ontcommon.Uint256, error) {
+	immutable, err := tx.IntoImmutable()
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invalid transaction:%s", err)
+	}
+	txHash := immutable.Hash()
+	contract, method, _ := nativeInvocation(tx)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, ok := this.txs[txHash.ToHexString()]; ok {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("duplicated transaction %s", txHash.ToHexString())
+	}
+	sentTx := &SentTx{
+		Tx:       tx,
+		Contract: contract,
+		Method:   method,
+	}
+	sentTx.Height = this.addBlock(nil, []*types.Transaction{immutable})
+	this.sent = append(this.sent, sentTx)
+	this.txs[txHash.ToHexString()] = sentTx
+	return txHash, nil
+}
+
+//WaitForGenerateBlock add blockCount blocks, 1 by default, at once
+func (this *Chain) WaitForGenerateBlock(timeout time.Duration, blockCount ...uint32) (bool, error) {
+	count := uint32(1)
+	if len(blockCount) > 0 {
+		count = blockCount[0]
+	}
+	for i := uint32(0); i < count; i++ {
+		this.AddBlock()
+	}
+	return true, nil
+}
+
+//GetSmartContractEvent return a successful event of a sent transaction, nil if not sent
+func (this *Chain) GetSmartContractEvent(txHash string) (*sdkcom.SmartContactEvent, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, ok := this.txs[txHash]; !ok {
+		return nil, nil
+	}
+	return &sdkcom.SmartContactEvent{
+		TxHash:      txHash,
+		State:       1,
+		GasConsumed: this.Gas,
+		Notify:      []*sdkcom.NotifyEventInfo{},
+	}, nil
+}
+
+func (this *Chain) GetBlockHeightByTxHash(txHash string) (uint32, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	sentTx, ok := this.txs[txHash]
+	if !ok {
+		return 0, fmt.Errorf("unknown transaction %s", txHash)
+	}
+	return sentTx.Height, nil
+}
+
+func storageKey(contract ontcommon.Address, key []byte) string {
+	return string(contract[:]) + string(key)
+}
+
+//preExecResult return a pre-execution result of gas and a hex result, the result item can only be built by
+//json decoding
+func preExecResult(gas uint64, result string) (*sdkcom.PreExecResult, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"State":  1,
+		"Gas":    gas,
+		"Result": result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal error:%s", err)
+	}
+	res := &sdkcom.PreExecResult{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	return res, nil
+}
+
+//nativeInvocation return contract, method and the code of params of a native invocation. The invocation
+//code ends with method, contract, version and the native invoke syscall
+func nativeInvocation(tx *types.MutableTransaction) (ontcommon.Address, string, []byte) {
+	invoke, ok := tx.Payload.(*payload.InvokeCode)
+	if !ok {
+		return ontcommon.ADDRESS_EMPTY, "", nil
+	}
+	code := invoke.Code
+	//version, syscall and the pushed syscall name
+	tail := 3 + len(cutils.NATIVE_INVOKE_NAME)
+	if len(code) < tail+1+ontcommon.ADDR_LEN+2 {
+		return ontcommon.ADDRESS_EMPTY, "", nil
+	}
+	end := len(code) - tail - ontcommon.ADDR_LEN
+	contract, err := ontcommon.AddressParseFromBytes(code[end:][:ontcommon.ADDR_LEN])
+	if err != nil || code[end-1] != ontcommon.ADDR_LEN {
+		return ontcommon.ADDRESS_EMPTY, "", nil
+	}
+	end--
+	for n := 1; n < end && n < 0x4b; n++ {
+		method := code[end-n : end]
+		if int(code[end-n-1]) == n && isMethodName(method) {
+			return contract, string(method), code[:end-n-1]
+		}
+	}
+	return contract, "", nil
+}
+
+func isMethodName(data []byte) bool {
+	for _, c := range data {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
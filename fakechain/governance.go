@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fakechain
+
+import (
+	"bytes"
+
+	"github.com/ontio/ontology-tool/client"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/serialization"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//Setters of governance storage items, encoded as the governance contract does and stored under the keys
+//client reads
+
+//SetGovernanceAdmin set the operator of the global params contract
+func (this *Chain) SetGovernanceAdmin(admin ontcommon.Address) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	utils.EncodeAddress(sink, admin)
+	this.Put(utils.ParamContractAddress, client.GovernanceAdminKey(), sink.Bytes())
+}
+
+//SetGovernanceView set the current governance view
+func (this *Chain) SetGovernanceView(governanceView *governance.GovernanceView) error {
+	buf := new(bytes.Buffer)
+	err := governanceView.Serialize(buf)
+	if err != nil {
+		return err
+	}
+	this.putGovernance([]byte(governance.GOVERNANCE_VIEW), buf.Bytes())
+	return nil
+}
+
+//SetVbftConfig set the vbft config in effect
+func (this *Chain) SetVbftConfig(config *governance.Configuration) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	config.Serialization(sink)
+	this.putGovernance([]byte(governance.VBFT_CONFIG), sink.Bytes())
+}
+
+//SetPreConfig set the vbft config taking effect in next round
+func (this *Chain) SetPreConfig(preConfig *governance.PreConfig) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	preConfig.Serialization(sink)
+	this.putGovernance([]byte(governance.PRE_CONFIG), sink.Bytes())
+}
+
+//SetGlobalParam set the global params
+func (this *Chain) SetGlobalParam(globalParam *governance.GlobalParam) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	globalParam.Serialization(sink)
+	this.putGovernance([]byte(governance.GLOBAL_PARAM), sink.Bytes())
+}
+
+//SetGlobalParam2 set the global params 2
+func (this *Chain) SetGlobalParam2(globalParam2 *governance.GlobalParam2) error {
+	sink := ontcommon.NewZeroCopySink(nil)
+	err := globalParam2.Serialization(sink)
+	if err != nil {
+		return err
+	}
+	this.putGovernance([]byte(governance.GLOBAL_PARAM2), sink.Bytes())
+	return nil
+}
+
+//SetSplitCurve set the split curve
+func (this *Chain) SetSplitCurve(splitCurve *governance.SplitCurve) error {
+	sink := ontcommon.NewZeroCopySink(nil)
+	err := splitCurve.Serialization(sink)
+	if err != nil {
+		return err
+	}
+	this.putGovernance([]byte(governance.SPLIT_CURVE), sink.Bytes())
+	return nil
+}
+
+//SetPeerPoolMap set the peer pool of view
+func (this *Chain) SetPeerPoolMap(view uint32, peerPoolMap *governance.PeerPoolMap) error {
+	sink := ontcommon.NewZeroCopySink(nil)
+	err := peerPoolMap.Serialization(sink)
+	if err != nil {
+		return err
+	}
+	this.putGovernance(client.PeerPoolMapKey(view), sink.Bytes())
+	return nil
+}
+
+//SetAuthorizeInfo set what authorizeInfo.Address authorized to authorizeInfo.PeerPubkey
+func (this *Chain) SetAuthorizeInfo(authorizeInfo *governance.AuthorizeInfo) error {
+	key, err := client.AuthorizeInfoKey(authorizeInfo.PeerPubkey, authorizeInfo.Address)
+	if err != nil {
+		return err
+	}
+	sink := ontcommon.NewZeroCopySink(nil)
+	authorizeInfo.Serialization(sink)
+	this.putGovernance(key, sink.Bytes())
+	return nil
+}
+
+//SetBlackList put item.PeerPubkey in the black list
+func (this *Chain) SetBlackList(item *governance.BlackListItem) error {
+	key, err := client.BlackListKey(item.PeerPubkey)
+	if err != nil {
+		return err
+	}
+	sink := ontcommon.NewZeroCopySink(nil)
+	item.Serialization(sink)
+	this.putGovernance(key, sink.Bytes())
+	return nil
+}
+
+//SetTotalStake set the total stake of totalStake.Address
+func (this *Chain) SetTotalStake(totalStake *governance.TotalStake) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	totalStake.Serialization(sink)
+	this.putGovernance(client.TotalStakeKey(totalStake.Address), sink.Bytes())
+}
+
+//SetPenaltyStake set the penalty stake of penaltyStake.PeerPubkey
+func (this *Chain) SetPenaltyStake(penaltyStake *governance.PenaltyStake) error {
+	key, err := client.PenaltyStakeKey(penaltyStake.PeerPubkey)
+	if err != nil {
+		return err
+	}
+	sink := ontcommon.NewZeroCopySink(nil)
+	penaltyStake.Serialization(sink)
+	this.putGovernance(key, sink.Bytes())
+	return nil
+}
+
+//SetAttributes set the attributes of peerAttributes.PeerPubkey
+func (this *Chain) SetAttributes(peerAttributes *governance.PeerAttributes) error {
+	key, err := client.PeerAttributesKey(peerAttributes.PeerPubkey)
+	if err != nil {
+		return err
+	}
+	sink := ontcommon.NewZeroCopySink(nil)
+	peerAttributes.Serialization(sink)
+	this.putGovernance(key, sink.Bytes())
+	return nil
+}
+
+//SetSplitFeeAddress set the split fee of splitFeeAddress.Address
+func (this *Chain) SetSplitFeeAddress(splitFeeAddress *governance.SplitFeeAddress) {
+	sink := ontcommon.NewZeroCopySink(nil)
+	splitFeeAddress.Serialization(sink)
+	this.putGovernance(client.SplitFeeAddressKey(splitFeeAddress.Address), sink.Bytes())
+}
+
+//SetSplitFee set the total split fee not withdrawn yet
+func (this *Chain) SetSplitFee(splitFee uint64) error {
+	buf := new(bytes.Buffer)
+	err := serialization.WriteUint64(buf, splitFee)
+	if err != nil {
+		return err
+	}
+	this.putGovernance([]byte(governance.SPLIT_FEE), buf.Bytes())
+	return nil
+}
+
+//SetPromisePos set the promise pos of promisePos.PeerPubkey
+func (this *Chain) SetPromisePos(promisePos *governance.PromisePos) error {
+	key, err := client.PromisePosKey(promisePos.PeerPubkey)
+	if err != nil {
+		return err
+	}
+	sink := ontcommon.NewZeroCopySink(nil)
+	promisePos.Serialization(sink)
+	this.putGovernance(key, sink.Bytes())
+	return nil
+}
+
+func (this *Chain) putGovernance(key, value []byte) {
+	this.Put(utils.GovernanceContractAddress, key, value)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+//Package fixture record the json rpc requests and responses of a run into a file, and serve them back from a
+//local stub node, so query methods can be replayed against a captured chain state
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+//Fixture is the json rpc exchanges of a run in the order they were sent
+type Fixture struct {
+	//Node is the address the exchanges were recorded from
+	Node      string
+	Exchanges []*Exchange
+}
+
+//Exchange is a json rpc request and the response of node
+type Exchange struct {
+	Method   string
+	Params   json.RawMessage
+	Response json.RawMessage
+}
+
+//rpcRequest is the part of a json rpc request an exchange is matched by
+type rpcRequest struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+//Load read a fixture file
+func Load(path string) (*Fixture, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture error:%s", err)
+	}
+	fixture := &Fixture{}
+	err = json.Unmarshal(data, fixture)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal fixture %s error:%s", path, err)
+	}
+	//params are indented in the file, they are compared compact as recorded
+	for _, exchange := range fixture.Exchanges {
+		exchange.Params = compactJson(exchange.Params)
+	}
+	return fixture, nil
+}
+
+//Save write the fixture to path
+func (this *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Marshal fixture error:%s", err)
+	}
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("write fixture error:%s", err)
+	}
+	return nil
+}
+
+//compactJson return data without insignificant spaces, so params are compared by value
+func compactJson(data []byte) []byte {
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	log4 "github.com/alecthomas/log4go"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/core"
+	gov "github.com/ontio/ontology-tool/methods/smartcontract/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//rpcResponse is the part of a json rpc response of ontology the tests check
+type rpcResponse struct {
+	Id     json.RawMessage `json:"id"`
+	Error  int64           `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+//call send a json rpc request to address by client
+func call(t *testing.T, client *http.Client, address string, id int, method string, params string) *rpcResponse {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":"%d","method":"%s","params":%s}`, id, method, params)
+	resp, err := client.Post(address, "application/json", bytes.NewReader([]byte(body)))
+	if err != nil {
+		t.Fatalf("post %s error:%s", method, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read %s response error:%s", method, err)
+	}
+	rpcResp := &rpcResponse{}
+	err = json.Unmarshal(data, rpcResp)
+	if err != nil {
+		t.Fatalf("json.Unmarshal %s response error:%s", method, err)
+	}
+	return rpcResp
+}
+
+//newNode start a node answering a request by its method and params, so every answer tells what it answers
+func newNode() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpcReq := &rpcRequest{}
+		json.NewDecoder(r.Body).Decode(rpcReq)
+		result, _ := json.Marshal(rpcReq.Method + string(compactJson(rpcReq.Params)))
+		resp, _ := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      rpcReq.Id,
+			"error":   0,
+			"desc":    "SUCCESS",
+			"result":  json.RawMessage(result),
+		})
+		w.Write(resp)
+	}))
+}
+
+func TestRecordReplay(t *testing.T) {
+	node := newNode()
+	defer node.Close()
+	recorder := NewRecorder(node.URL, nil)
+	recordClient := &http.Client{Transport: recorder}
+	recorded := []struct {
+		method string
+		params string
+	}{
+		{method: "getstorage", params: `["0700000000000000000000000000000000000000", "6b31"]`},
+		{method: "getstorage", params: `["0700000000000000000000000000000000000000","6b32"]`},
+		{method: "sendrawtransaction", params: `["00d1aa", 1]`},
+		{method: "getblockcount", params: `[]`},
+	}
+	for i, req := range recorded {
+		call(t, recordClient, node.URL, i, req.method, req.params)
+	}
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	err := recorder.Save(path)
+	if err != nil {
+		t.Fatalf("Save error:%s", err)
+	}
+	fixture, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error:%s", err)
+	}
+	if fixture.Node != node.URL || len(fixture.Exchanges) != len(recorded) {
+		t.Fatalf("fixture of %s with %d exchanges, should be of %s with %d", fixture.Node, len(fixture.Exchanges), node.URL, len(recorded))
+	}
+	node.Close()
+
+	server, err := NewServer(fixture)
+	if err != nil {
+		t.Fatalf("NewServer error:%s", err)
+	}
+	defer server.Stop()
+	tests := []struct {
+		name   string
+		method string
+		params string
+		want   string
+	}{
+		{name: "same params", method: "getstorage", params: `["0700000000000000000000000000000000000000","6b32"]`,
+			want: `getstorage["0700000000000000000000000000000000000000","6b32"]`},
+		{name: "same params spaced", method: "getstorage", params: `[ "0700000000000000000000000000000000000000", "6b31" ]`,
+			want: `getstorage["0700000000000000000000000000000000000000","6b31"]`},
+		{name: "same params used up", method: "getstorage", params: `["0700000000000000000000000000000000000000","6b31"]`,
+			want: `getstorage["0700000000000000000000000000000000000000","6b31"]`},
+		{name: "key never recorded", method: "getstorage", params: `["0700000000000000000000000000000000000000","6b33"]`},
+		{name: "signed transaction of another run", method: "sendrawtransaction", params: `["00d1bb",1]`,
+			want: `sendrawtransaction["00d1aa",1]`},
+		{name: "signed transaction used up", method: "sendrawtransaction", params: `["00d1cc",1]`,
+			want: `sendrawtransaction["00d1aa",1]`},
+		{name: "method never recorded", method: "getbalance", params: `["AR7kbmVDA6Cx9o46WGLWsTaifqQ7Pnja9v"]`},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := call(t, http.DefaultClient, server.JsonRpcAddress, 100+i, test.method, test.params)
+			if string(resp.Id) != fmt.Sprintf(`"%d"`, 100+i) {
+				t.Fatalf("response id %s, should be the id of the request", resp.Id)
+			}
+			if test.want == "" {
+				if resp.Error == 0 {
+					t.Fatalf("result %s, should be an error", resp.Result)
+				}
+				return
+			}
+			var result string
+			json.Unmarshal(resp.Result, &result)
+			if resp.Error != 0 || result != test.want {
+				t.Fatalf("error %d result %s, should be %s", resp.Error, result, test.want)
+			}
+		})
+	}
+	unused := server.Unused()
+	if len(unused) != 1 || unused[0].Method != "getblockcount" {
+		t.Fatalf("%d exchanges unused, should be getblockcount only", len(unused))
+	}
+}
+
+//TestReplayGovernance replay a fixture recorded from a devnet, where a candidate registered with 100000 ONT got
+//500 ONT of authorization before a CommitDpos, through the governance methods
+func TestReplayGovernance(t *testing.T) {
+	const peerPubkey = "03ca47f4f2fde5b8f4a3dd547d2b0d71783dda0cdb9cecf8eee0ac83519c6f6837"
+	const authorizer = "AR7kbmVDA6Cx9o46WGLWsTaifqQ7Pnja9v"
+	fixture, err := Load(filepath.Join("testdata", "devnet_authorized.json"))
+	if err != nil {
+		t.Fatalf("Load error:%s", err)
+	}
+	server, err := NewServer(fixture)
+	if err != nil {
+		t.Fatalf("NewServer error:%s", err)
+	}
+	defer server.Stop()
+	ontSdk := sdk.NewOntologySdk()
+	ontSdk.NewRpcClient().SetAddress(server.JsonRpcAddress)
+	newEnv := func(params string) *core.Env {
+		return &core.Env{
+			Sdk:    ontSdk,
+			Chain:  ontSdk,
+			Config: config.DefConfig,
+			Params: &core.ParamSource{Data: json.RawMessage(params)},
+			Logger: make(log4.Logger),
+		}
+	}
+
+	result, err := gov.GetPeerPoolMap(context.Background(), newEnv(""))
+	if err != nil {
+		t.Fatalf("GetPeerPoolMap error:%s", err)
+	}
+	peerPoolMap := result.Outputs[0].Value.(*governance.PeerPoolMap)
+	item, ok := peerPoolMap.PeerPoolMap[peerPubkey]
+	if len(peerPoolMap.PeerPoolMap) != 8 || !ok {
+		t.Fatalf("%d peers, should be 7 genesis peers and the candidate", len(peerPoolMap.PeerPoolMap))
+	}
+	if item.Status != governance.CandidateStatus || item.InitPos != 100000 || item.TotalPos != 500 {
+		t.Fatalf("candidate status %d init pos %d total pos %d, should be candidate with 100000 and 500", item.Status, item.InitPos, item.TotalPos)
+	}
+
+	result, err = gov.GetAuthorizeInfo(context.Background(), newEnv(fmt.Sprintf(`{"Address":"%s","PeerPubkey":"%s"}`, authorizer, peerPubkey)))
+	if err != nil {
+		t.Fatalf("GetAuthorizeInfo error:%s", err)
+	}
+	info := result.Outputs[0].Value.(*governance.AuthorizeInfo)
+	if info.CandidatePos != 500 || info.NewPos != 0 || info.ConsensusPos != 0 {
+		t.Fatalf("authorize info %+v, should be 500 candidate pos", info)
+	}
+	if unused := server.Unused(); len(unused) != 0 {
+		t.Fatalf("%d exchanges unused, the methods should read what was recorded", len(unused))
+	}
+
+	//the authorization of another address was never recorded, it must not get the recorded one
+	_, err = gov.GetAuthorizeInfo(context.Background(), newEnv(fmt.Sprintf(`{"Address":"%s","PeerPubkey":"%s"}`, sdk.NewAccount().Address.ToBase58(), peerPubkey)))
+	if err == nil {
+		t.Fatalf("GetAuthorizeInfo of an address never recorded should fail")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+//Recorder is a http transport which keeps every json rpc request it carries with the response of node
+type Recorder struct {
+	transport http.RoundTripper
+	lock      sync.Mutex
+	fixture   *Fixture
+}
+
+//NewRecorder return a recorder sending by transport, the default transport if nil
+func NewRecorder(node string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{
+		transport: transport,
+		fixture:   &Fixture{Node: node},
+	}
+}
+
+//RoundTrip send req and record it with the response if it is a json rpc request answered by node
+func (this *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Method != http.MethodPost {
+		return this.transport.RoundTrip(req)
+	}
+	reqBody, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request error:%s", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	resp, err := this.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response error:%s", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	rpcReq := &rpcRequest{}
+	if json.Unmarshal(reqBody, rpcReq) != nil || rpcReq.Method == "" || !json.Valid(respBody) {
+		return resp, nil
+	}
+	exchange := &Exchange{
+		Method:   rpcReq.Method,
+		Params:   compactJson(rpcReq.Params),
+		Response: compactJson(respBody),
+	}
+	this.lock.Lock()
+	this.fixture.Exchanges = append(this.fixture.Exchanges, exchange)
+	this.lock.Unlock()
+	return resp, nil
+}
+
+//Save write the exchanges recorded so far to path
+func (this *Recorder) Save(path string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.fixture.Save(path)
+}
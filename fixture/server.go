@@ -0,0 +1,190 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+
+	log4 "github.com/alecthomas/log4go"
+)
+
+//LOOSE_METHODS are the json rpc methods whose params differ from run to run: signed transactions, and the hash
+//of a transaction its confirmation is polled by. A request of them with no exchange of the same params is answered
+//by the next unused exchange of the same method
+var LOOSE_METHODS = map[string]bool{
+	"sendrawtransaction":     true,
+	"getsmartcodeevent":      true,
+	"getblockheightbytxhash": true,
+	"getrawtransaction":      true,
+}
+
+//Server is a stub node answering json rpc requests with the responses of a fixture. A request is answered by
+//the next unused exchange of the same method and params, or of the same method for LOOSE_METHODS. The last answer
+//is repeated once the exchanges are used up, so polling keeps working. Any other request gets a json rpc error,
+//so a query of another storage key never gets the value of a recorded one
+type Server struct {
+	//JsonRpcAddress of the stub node
+	JsonRpcAddress string
+	lock           sync.Mutex
+	fixture        *Fixture
+	used           []bool
+	last           map[string]*Exchange
+	listener       net.Listener
+}
+
+//NewServer start a stub node of fixture on a free local port
+func NewServer(fixture *Fixture) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen error:%s", err)
+	}
+	server := &Server{
+		JsonRpcAddress: "http://" + listener.Addr().String(),
+		fixture:        fixture,
+		used:           make([]bool, len(fixture.Exchanges)),
+		last:           make(map[string]*Exchange),
+		listener:       listener,
+	}
+	go func() {
+		err := http.Serve(listener, server)
+		if err != nil && !isClosed(err) {
+			log4.Error("fixture server error:%s", err)
+		}
+	}()
+	log4.Info("Replay %d exchanges recorded from %s at %s", len(fixture.Exchanges), fixture.Node, server.JsonRpcAddress)
+	return server, nil
+}
+
+//Stop stop serving and warn of the exchanges not replayed, a run which no longer sends them has changed
+func (this *Server) Stop() {
+	this.listener.Close()
+	unused := this.Unused()
+	if len(unused) == 0 {
+		return
+	}
+	log4.Warn("%d of %d recorded exchanges not replayed", len(unused), len(this.fixture.Exchanges))
+	for _, exchange := range unused {
+		log4.Warn("Not replayed: %s %s", exchange.Method, exchange.Params)
+	}
+}
+
+//Unused return the exchanges not replayed yet
+func (this *Server) Unused() []*Exchange {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	unused := make([]*Exchange, 0)
+	for i, exchange := range this.fixture.Exchanges {
+		if !this.used[i] {
+			unused = append(unused, exchange)
+		}
+	}
+	return unused
+}
+
+func (this *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rpcReq := &rpcRequest{}
+	err = json.Unmarshal(body, rpcReq)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("json.Unmarshal request error:%s", err), http.StatusBadRequest)
+		return
+	}
+	exchange := this.match(rpcReq.Method, compactJson(rpcReq.Params))
+	var resp []byte
+	if exchange == nil {
+		log4.Warn("No recorded response of %s %s", rpcReq.Method, rpcReq.Params)
+		resp, err = json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      rpcReq.Id,
+			"error":   int64(-1),
+			"desc":    fmt.Sprintf("no recorded response of %s %s", rpcReq.Method, compactJson(rpcReq.Params)),
+			"result":  nil,
+		})
+	} else {
+		resp, err = withId(exchange.Response, rpcReq.Id)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+//match return the exchange answering a request, nil if there is none
+func (this *Server) match(method string, params []byte) *Exchange {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	index := -1
+	for i, exchange := range this.fixture.Exchanges {
+		if this.used[i] || exchange.Method != method {
+			continue
+		}
+		if bytes.Equal(exchange.Params, params) {
+			index = i
+			break
+		}
+		if index < 0 && LOOSE_METHODS[method] {
+			index = i
+		}
+	}
+	key := method + string(params)
+	if index < 0 {
+		if exchange, ok := this.last[key]; ok {
+			return exchange
+		}
+		if LOOSE_METHODS[method] {
+			return this.last[method]
+		}
+		return nil
+	}
+	exchange := this.fixture.Exchanges[index]
+	this.used[index] = true
+	this.last[key] = exchange
+	this.last[method] = exchange
+	return exchange
+}
+
+//withId return the response with id of the request, the recorded id is of the recording run
+func withId(response []byte, id json.RawMessage) ([]byte, error) {
+	resp := make(map[string]json.RawMessage)
+	err := json.Unmarshal(response, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal recorded response error:%s", err)
+	}
+	if len(id) > 0 {
+		resp["id"] = id
+	}
+	return json.Marshal(resp)
+}
+
+func isClosed(err error) bool {
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Err.Error() == "use of closed network connection"
+}
@@ -6,6 +6,7 @@ require (
 	github.com/FactomProject/basen v0.0.0-20150613233007-fe3947df716e // indirect
 	github.com/alecthomas/log4go v0.0.0-20180109082532-d146e6b86faa
 	github.com/cmars/basen v0.0.0-20150613233007-fe3947df716e // indirect
+	github.com/howeyc/gopass v0.0.0-20190910152052-7cb4b85ec19c
 	github.com/ontio/ontology v1.11.1-0.20200805022519-c344007e9252
 	github.com/ontio/ontology-crypto v1.0.9
 	github.com/ontio/ontology-go-sdk v1.11.1
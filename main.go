@@ -18,14 +18,23 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"math/rand"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	log4 "github.com/alecthomas/log4go"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
 	"github.com/ontio/ontology-tool/config"
 	"github.com/ontio/ontology-tool/core"
+	"github.com/ontio/ontology-tool/devnet"
+	"github.com/ontio/ontology-tool/fixture"
 	_ "github.com/ontio/ontology-tool/methods"
 )
 
@@ -33,30 +42,284 @@ var (
 	Config    string //config file
 	LogConfig string //Log config file
 	Methods   string //Methods list in cmdline
+	Scenario  string //Scenario file
+	ParamsDir string //Directory of default params files
+	Report    string //Report file prefix
+	List      bool   //Print method catalog
+	Describe  string //Method to describe
+	DryRun    bool   //Print transactions instead of sending
+	Export    string //Export unsigned multi-sign transactions to file
+	Network   string //Network profile of config
+	Password  string //Source of wallet passwords
+	Devnet    bool   //Run methods on an in-process devnet
+	Record    string //Record json rpc exchanges to fixture file
+	Replay    string //Replay json rpc exchanges of fixture file
+	Output    string //Format of method outputs
 )
 
 func init() {
 	flag.StringVar(&Config, "cfg", "./config.json", "Config of ontology-tool")
 	flag.StringVar(&LogConfig, "lfg", "./log4go.xml", "Log config of ontology-tool")
-	flag.StringVar(&Methods, "t", "", "methods to run. use ',' to split methods")
+	flag.StringVar(&Methods, "t", "", "methods to run. use ',' to split methods, and Method@path to set params file of a method")
+	flag.StringVar(&ParamsDir, "params-dir", core.DEFAULT_PARAMS_DIR, "directory of default params files <Method>.json")
+	flag.StringVar(&Scenario, "s", "", "scenario file of steps to run. can not be used with -t")
+	flag.StringVar(&Report, "report", "", "write run report to <report>.json and JUnit XML <report>.xml")
+	flag.BoolVar(&List, "list", false, "print registered methods and exit")
+	flag.StringVar(&Describe, "describe", "", "print params schema and example params json of a method and exit")
+	flag.BoolVar(&DryRun, "dry-run", false, "build and sign transactions, print them instead of sending")
+	flag.StringVar(&Export, "export", "", "write unsigned multi-sign transactions to file instead of sending, no password needed")
+	flag.StringVar(&Network, "network", "", "network profile of config to use, such as mainnet, polaris or local")
+	flag.BoolVar(&Devnet, "devnet", false, "start an in-process single node devnet of Devnet config and run the methods of -t or -s on it. the devnet command serves it without methods")
+	flag.StringVar(&Record, "record", "", "record every json rpc request and response of the run into fixture file")
+	flag.StringVar(&Replay, "replay", "", "run methods on a local stub node answering json rpc requests from fixture file")
+	flag.StringVar(&Output, "output", "", "format of method outputs: table, json or csv, default is Output of config or table")
+	flag.StringVar(&Password, "password", "", "source of wallet passwords: prompt, env, file:<path> or fd:<n>, default is Password of config or prompt")
+	flag.Usage = usage
 	flag.Parse()
 }
 
+//COMMAND_DEVNET serve the devnet of config until interrupted
+const COMMAND_DEVNET = "devnet"
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command]\n\n", os.Args[0])
+	fmt.Fprintln(flag.CommandLine.Output(), "Runs the methods of -t or -s. Commands:")
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s\tstart an in-process single node devnet of Devnet config and serve it until interrupted\n\n", COMMAND_DEVNET)
+	fmt.Fprintln(flag.CommandLine.Output(), "Flags:")
+	flag.PrintDefaults()
+}
+
+//checkFlags return error if flags and command can not be used together
+func checkFlags() error {
+	if flag.NArg() > 1 {
+		return fmt.Errorf("only one command can be given, got %s", strings.Join(flag.Args(), " "))
+	}
+	command := flag.Arg(0)
+	if Replay != "" && (Devnet || Record != "" || command == COMMAND_DEVNET) {
+		return fmt.Errorf("-replay can not be used with -devnet, -record or the %s command", COMMAND_DEVNET)
+	}
+	switch command {
+	case "":
+		if Devnet && Methods == "" && Scenario == "" {
+			return fmt.Errorf("-devnet needs methods of -t or -s, the %s command serves a devnet without methods", COMMAND_DEVNET)
+		}
+	case COMMAND_DEVNET:
+		if Methods != "" || Scenario != "" {
+			return fmt.Errorf("the %s command serves a devnet, use -devnet to run methods on it", COMMAND_DEVNET)
+		}
+	default:
+		return fmt.Errorf("unknown command %s, should be %s", command, COMMAND_DEVNET)
+	}
+	return nil
+}
+
 func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	if List {
+		core.OntTool.ListMethods(os.Stdout)
+		return 0
+	}
+	if Describe != "" {
+		err := core.OntTool.DescribeMethod(os.Stdout, Describe)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
 	rand.Seed(time.Now().UnixNano())
 	log4.LoadConfiguration(LogConfig)
 	defer time.Sleep(time.Second)
+	err := checkFlags()
+	if err != nil {
+		log4.Error("%s", err)
+		return 1
+	}
 
-	err := config.DefConfig.Init(Config)
+	err = config.DefConfig.Init(Config)
 	if err != nil {
 		log4.Error("DefConfig.Init error:%s", err)
-		return
+		return 1
+	}
+	err = config.DefConfig.SelectNetwork(Network)
+	if err != nil {
+		log4.Error("SelectNetwork error:%s", err)
+		return 1
+	}
+	log4.Info("Network:%s", config.DefConfig.NetworkName())
+	if Password != "" {
+		config.DefConfig.Password = Password
+	}
+	if DryRun {
+		config.DefConfig.DryRun = true
+	}
+	config.DefConfig.Export = Export
+	if Output != "" {
+		config.DefConfig.Output = Output
+	}
+	switch config.DefConfig.Output {
+	case config.OUTPUT_TABLE, config.OUTPUT_JSON, config.OUTPUT_CSV:
+	default:
+		log4.Error("unknown output format %s, should be %s, %s or %s", config.DefConfig.Output,
+			config.OUTPUT_TABLE, config.OUTPUT_JSON, config.OUTPUT_CSV)
+		return 1
 	}
 
-	methods := make([]string, 0)
-	if Methods != "" {
-		methods = strings.Split(Methods, ",")
+	steps := make([]*core.Step, 0)
+	if Scenario != "" {
+		if Methods != "" {
+			log4.Error("-t and -s can not be used together")
+			return 1
+		}
+		scenario, err := core.LoadScenario(Scenario)
+		if err != nil {
+			log4.Error("LoadScenario error:%s", err)
+			return 1
+		}
+		log4.Info("Load scenario:%s steps:%d", scenario.Name, len(scenario.Steps))
+		steps = scenario.Steps
+	} else if Methods != "" {
+		steps = core.NewMethodSteps(strings.Split(Methods, ","))
 	}
 
-	core.OntTool.Start(methods)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go waitSignal(cancel)
+
+	if Devnet || flag.Arg(0) == COMMAND_DEVNET {
+		net, err := startDevnet(ctx)
+		if err != nil {
+			log4.Error("startDevnet error:%s", err)
+			return 1
+		}
+		defer net.Stop()
+		if flag.Arg(0) == COMMAND_DEVNET {
+			log4.Info("Devnet json rpc %s, interrupt to stop", net.JsonRpcAddress)
+			<-ctx.Done()
+			return 0
+		}
+	}
+	if Replay != "" {
+		server, err := startReplay(Replay)
+		if err != nil {
+			log4.Error("startReplay error:%s", err)
+			return 1
+		}
+		defer server.Stop()
+	}
+	if Record != "" {
+		recorder, err := startRecord()
+		if err != nil {
+			log4.Error("startRecord error:%s", err)
+			return 1
+		}
+		defer func() {
+			err := recorder.Save(Record)
+			if err != nil {
+				log4.Error("save fixture error:%s", err)
+				return
+			}
+			log4.Info("Json rpc exchanges recorded to %s", Record)
+		}()
+	}
+
+	core.OntTool.SetReport(Report)
+	core.OntTool.SetParamsDir(ParamsDir)
+	if !core.OntTool.Start(ctx, steps) {
+		return 1
+	}
+	return 0
+}
+
+//startDevnet start the devnet of config, fund its accounts and point the config to it
+func startDevnet(ctx context.Context) (*devnet.Devnet, error) {
+	cfg := config.DefConfig.Devnet
+	if cfg == nil {
+		cfg = &config.DevnetConfig{}
+	}
+	paths := cfg.Bookkeepers
+	if len(paths) == 0 {
+		paths = []string{config.DefConfig.Wallet}
+	}
+	ontSdk := sdk.NewOntologySdk()
+	bookkeepers := make([]*sdk.Account, 0, len(paths))
+	for _, path := range paths {
+		bookkeeper, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return nil, fmt.Errorf("bookkeeper %s error:%s", path, err)
+		}
+		bookkeepers = append(bookkeepers, bookkeeper)
+	}
+	net, err := devnet.Start(cfg, bookkeepers)
+	if err != nil {
+		return nil, err
+	}
+	err = net.SetCandidateFee(ctx, bookkeepers, cfg.CandidateFee)
+	if err == nil {
+		err = net.Fund(ctx, bookkeepers, cfg.Fund)
+	}
+	if err != nil {
+		net.Stop()
+		return nil, err
+	}
+	useLocalNode(net.JsonRpcAddress)
+	config.DefConfig.GenesisHash = ""
+	config.DefConfig.Protected = false
+	config.DefConfig.GasPrice = 0
+	config.DefConfig.GasPriceFromNode = false
+	return net, nil
+}
+
+//startReplay start a stub node of the fixture file and point the config to it
+func startReplay(path string) (*fixture.Server, error) {
+	recorded, err := fixture.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	server, err := fixture.NewServer(recorded)
+	if err != nil {
+		return nil, err
+	}
+	useLocalNode(server.JsonRpcAddress)
+	return server, nil
+}
+
+//startRecord send json rpc requests of the run by a recorder
+func startRecord() (*fixture.Recorder, error) {
+	if config.DefConfig.Transport != config.TRANSPORT_RPC && config.DefConfig.Transport != "" {
+		return nil, fmt.Errorf("only json rpc is recorded, transport is %s", config.DefConfig.Transport)
+	}
+	if config.DefConfig.ConfirmByWebSocket {
+		return nil, fmt.Errorf("only json rpc is recorded, ConfirmByWebSocket is set")
+	}
+	node := config.DefConfig.JsonRpcAddress
+	if len(config.DefConfig.Endpoints) > 0 {
+		node = strings.Join(config.DefConfig.Endpoints, ",")
+	}
+	recorder := fixture.NewRecorder(node, nil)
+	common.SetRpcTransport(recorder)
+	return recorder, nil
+}
+
+//useLocalNode point the config to the json rpc endpoint of a local node, other endpoints are not used
+func useLocalNode(address string) {
+	config.DefConfig.JsonRpcAddress = address
+	config.DefConfig.RestfulAddress = ""
+	config.DefConfig.WebSocketAddress = ""
+	config.DefConfig.Transport = config.TRANSPORT_RPC
+	config.DefConfig.ConfirmByWebSocket = false
+	config.DefConfig.Endpoints = nil
+}
+
+//waitSignal cancel the running methods when interrupted
+func waitSignal(cancel context.CancelFunc) {
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sc
+	log4.Info("Receive signal %v, cancel running methods", sig)
+	cancel()
+	signal.Stop(sc)
 }
@@ -18,9 +18,11 @@
 package methods
 
 import (
+	"github.com/ontio/ontology-tool/methods/multisign"
 	"github.com/ontio/ontology-tool/methods/smartcontract"
 )
 
 func init() {
 	smartcontract.RegisterSmartContract()
+	multisign.RegisterMultiSign()
 }
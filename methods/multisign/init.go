@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multisign
+
+import (
+	"github.com/ontio/ontology-tool/core"
+)
+
+func RegisterMultiSign() {
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "SignMultiSignTx",
+		Method:      SignMultiSignTx,
+		Description: "Add signatures of the accounts of Path, selected by wallet#address or wallet#label, to an exported multi-sign tx file",
+		Params:      new(SignMultiSignTxParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetMultiSignTxStatus",
+		Method:      GetMultiSignTxStatus,
+		Description: "Show members who have and have not signed a multi-sign tx file against its M-of-N threshold",
+		Params:      new(MultiSignTxFileParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "SendMultiSignTx",
+		Method:      SendMultiSignTx,
+		Description: "Broadcast a multi-sign tx file once its M-of-N threshold is met",
+		Params:      new(MultiSignTxFileParam),
+		SendTx:      true,
+	})
+}
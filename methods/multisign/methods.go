@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multisign
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/core"
+)
+
+type SignMultiSignTxParam struct {
+	TxFile string
+	//Path of signing accounts, "<wallet path>[#<address or label>]", the default account of wallet if no selector
+	Path []string
+}
+
+type MultiSignTxFileParam struct {
+	TxFile string
+}
+
+func SignMultiSignTx(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	signParam := new(SignMultiSignTxParam)
+	err := env.Params.Load(signParam)
+	if err != nil {
+		return result, err
+	}
+	multiSignTx, err := common.LoadMultiSignTx(signParam.TxFile)
+	if err != nil {
+		return result, err
+	}
+	for _, path := range signParam.Path {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		user, err := common.GetAccountByPassword(env.Sdk, path)
+		if err != nil {
+			return result, err
+		}
+		err = multiSignTx.Sign(env.Sdk, user)
+		if err != nil {
+			return result, fmt.Errorf("sign by %s error:%s", user.Address.ToBase58(), err)
+		}
+		env.Logger.Info("Signed by %s", user.Address.ToBase58())
+	}
+	err = multiSignTx.Save(signParam.TxFile)
+	if err != nil {
+		return result, err
+	}
+	return result, addStatus(env, result, multiSignTx)
+}
+
+func GetMultiSignTxStatus(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	fileParam := new(MultiSignTxFileParam)
+	err := env.Params.Load(fileParam)
+	if err != nil {
+		return result, err
+	}
+	multiSignTx, err := common.LoadMultiSignTx(fileParam.TxFile)
+	if err != nil {
+		return result, err
+	}
+	return result, addStatus(env, result, multiSignTx)
+}
+
+func SendMultiSignTx(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	fileParam := new(MultiSignTxFileParam)
+	err := env.Params.Load(fileParam)
+	if err != nil {
+		return result, err
+	}
+	multiSignTx, err := common.LoadMultiSignTx(fileParam.TxFile)
+	if err != nil {
+		return result, err
+	}
+	status, err := multiSignTx.Status()
+	if err != nil {
+		return result, err
+	}
+	if !status.Ready() {
+		return result, fmt.Errorf("tx %s has %d signatures, %d of %d needed", multiSignTx.TxHash, len(status.Signed), status.M, status.N)
+	}
+	tx, err := multiSignTx.GetTx()
+	if err != nil {
+		return result, err
+	}
+	preExec, err := common.PreExecTransaction(env.Chain, tx, nil)
+	if err != nil {
+		return result, err
+	}
+	result.AddOutput("preExec", preExec)
+	if preExec.Gas > tx.GasLimit {
+		return result, fmt.Errorf("estimated gas %d exceeds gas limit %d, export the tx again", preExec.Gas, tx.GasLimit)
+	}
+	txHash, err := common.SendTransaction(env.Chain, tx, nil)
+	if err != nil {
+		return result, fmt.Errorf("SendTransaction error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	result.AddOutput("tx", &multiSignTxSummary{
+		TxHash:   txHash.ToHexString(),
+		Contract: multiSignTx.Contract,
+		Method:   multiSignTx.Method,
+		Payer:    multiSignTx.Payer,
+	})
+	env.Logger.Info("%s %s txHash is: %s", multiSignTx.Contract, multiSignTx.Method, txHash.ToHexString())
+	return result, nil
+}
+
+//addStatus add the transaction and its signature status to result
+func addStatus(env *core.Env, result *core.Result, multiSignTx *common.MultiSignTx) error {
+	status, err := multiSignTx.Status()
+	if err != nil {
+		return err
+	}
+	result.AddOutput("tx", &multiSignTxSummary{
+		TxHash:   multiSignTx.TxHash,
+		Contract: multiSignTx.Contract,
+		Method:   multiSignTx.Method,
+		Payer:    multiSignTx.Payer,
+	})
+	result.AddOutput("status", status)
+	if status.Ready() {
+		env.Logger.Info("Tx %s is ready to send", multiSignTx.TxHash)
+	} else {
+		env.Logger.Info("Tx %s needs %d more signatures", multiSignTx.TxHash, int(status.M)-len(status.Signed))
+	}
+	return nil
+}
+
+//multiSignTxSummary is what a multi-sign tx file invokes
+type multiSignTxSummary struct {
+	TxHash   string
+	Contract string
+	Method   string
+	Payer    string
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/core"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+type SimulateElectionParam struct {
+	//K overrides K of the vbft config the next commitDpos elects by, 0 keeps it
+	K uint32
+	//L overrides L of the vbft config the pos table is built by, 0 keeps it
+	L uint32
+	//Authorizations are applied to the peer pool before the election, a negative Pos is an unauthorization
+	Authorizations []*SimulatedAuthorization
+}
+
+type SimulatedAuthorization struct {
+	PeerPubkey string
+	Pos        int64
+}
+
+//electionConfig is what the simulated commitDpos elects by
+type electionConfig struct {
+	View         uint32
+	NextView     uint32
+	K            uint32
+	L            uint32
+	Candidates   int
+	PosTableSize uint64
+}
+
+//electedPeer is a peer of the pool and its status after the simulated commitDpos
+type electedPeer struct {
+	Rank       int
+	Index      uint32
+	PeerPubkey string
+	Status     string
+	NextStatus string
+	Stake      uint64
+	PosSlots   uint64
+	PosPercent float64
+	Change     string
+}
+
+//SimulateElection compute what the next commitDpos does with the peer pool of the current view, the same way as
+//the governance contract elects consensus nodes and vbft builds the pos table of the new chain config
+func SimulateElection(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	param := new(SimulateElectionParam)
+	err := env.Params.Load(param)
+	if err != nil {
+		return result, err
+	}
+	gov := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	view, err := gov.GetView()
+	if err != nil {
+		return result, fmt.Errorf("GetView error:%s", err)
+	}
+	peerPoolMap, err := gov.GetPeerPoolMapOfView(view)
+	if err != nil {
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
+	}
+	config, err := gov.GetNextVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetNextVbftConfig error:%s", err)
+	}
+	k, l := config.K, config.L
+	if param.K != 0 {
+		k = param.K
+	}
+	if param.L != 0 {
+		l = param.L
+	}
+	if len(param.Authorizations) > 0 {
+		err = applyAuthorizations(ctx, env, gov, peerPoolMap, param.Authorizations, result)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	var candidates, others []*governance.PeerPoolItem
+	for _, item := range peerPoolMap.PeerPoolMap {
+		if item.Status == governance.CandidateStatus || item.Status == governance.ConsensusStatus {
+			candidates = append(candidates, item)
+		} else {
+			others = append(others, item)
+		}
+	}
+	if k == 0 {
+		return result, fmt.Errorf("K of vbft config is 0")
+	}
+	if len(candidates) < int(k) {
+		return result, fmt.Errorf("commitDpos would fail, %d candidate and consensus peers are less than K %d", len(candidates), k)
+	}
+	if l/k < 2 {
+		return result, fmt.Errorf("vbft would fail, L %d must be at least twice K %d", l, k)
+	}
+	scale := l/k - 1
+	//same order as commitDpos: stake descending, then peer public key descending
+	sort.SliceStable(candidates, func(i, j int) bool {
+		stakeI, stakeJ := candidates[i].InitPos+candidates[i].TotalPos, candidates[j].InitPos+candidates[j].TotalPos
+		if stakeI != stakeJ {
+			return stakeI > stakeJ
+		}
+		return candidates[i].PeerPubkey > candidates[j].PeerPubkey
+	})
+	var sum uint64
+	for _, item := range candidates[:k] {
+		sum += item.InitPos + item.TotalPos
+	}
+
+	peers := make([]*electedPeer, 0, len(peerPoolMap.PeerPoolMap))
+	var posTableSize uint64
+	entered, left, removed := 0, 0, 0
+	for i, item := range candidates {
+		peer := &electedPeer{
+			Rank:       i + 1,
+			Index:      item.Index,
+			PeerPubkey: item.PeerPubkey,
+			Status:     statusName(item.Status),
+			NextStatus: statusName(governance.CandidateStatus),
+			Stake:      item.InitPos + item.TotalPos,
+		}
+		if i < int(k) {
+			peer.NextStatus = statusName(governance.ConsensusStatus)
+			//pos table ranks of vbft GenesisChainConfig
+			peer.PosSlots = 1
+			if sum > 0 && peer.Stake > 0 {
+				peer.PosSlots = uint64(math.Ceil(float64(peer.Stake) * float64(scale) * float64(k) / float64(sum)))
+			}
+			posTableSize += peer.PosSlots
+			if item.Status != governance.ConsensusStatus {
+				peer.Change = "enters consensus"
+				entered++
+			}
+		} else if item.Status == governance.ConsensusStatus {
+			peer.Change = "leaves consensus"
+			left++
+		}
+		peers = append(peers, peer)
+	}
+	for _, peer := range peers {
+		if posTableSize > 0 {
+			peer.PosPercent = math.Round(float64(peer.PosSlots)*10000/float64(posTableSize)) / 100
+		}
+	}
+
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].Index < others[j].Index
+	})
+	for _, item := range others {
+		peer := &electedPeer{
+			Index:      item.Index,
+			PeerPubkey: item.PeerPubkey,
+			Status:     statusName(item.Status),
+			NextStatus: statusName(item.Status),
+			Stake:      item.InitPos + item.TotalPos,
+		}
+		switch item.Status {
+		case governance.QuitingStatus:
+			peer.NextStatus = ""
+			peer.Change = "quits, leaves pool"
+			removed++
+		case governance.BlackStatus:
+			peer.NextStatus = ""
+			peer.Change = "blacklisted, leaves pool"
+			removed++
+		case governance.QuitConsensusStatus:
+			peer.NextStatus = statusName(governance.QuitingStatus)
+			peer.Change = "leaves consensus, leaves pool next view"
+			left++
+		case governance.RegisterCandidateStatus:
+			peer.Change = "not approved, not elected"
+		}
+		peers = append(peers, peer)
+	}
+	env.Logger.Info("Next commitDpos of view %d: %d peers enter consensus, %d leave consensus, %d leave pool",
+		view, entered, left, removed)
+
+	result.AddOutput("config", &electionConfig{
+		View:         view,
+		NextView:     view + 1,
+		K:            k,
+		L:            l,
+		Candidates:   len(candidates),
+		PosTableSize: posTableSize,
+	})
+	result.AddOutput("peers", peers)
+	return result, nil
+}
+
+//applyAuthorizations add what-if authorizations to the total pos of peers. An authorization the governance
+//contract would reject is skipped with a warning
+func applyAuthorizations(ctx context.Context, env *core.Env, gov *client.GovernanceClient, peerPoolMap *governance.PeerPoolMap,
+	authorizations []*SimulatedAuthorization, result *core.Result) error {
+	globalParam, err := gov.GetGlobalParam()
+	if err != nil {
+		return fmt.Errorf("GetGlobalParam error:%s", err)
+	}
+	globalParam2, err := gov.GetGlobalParam2()
+	if err != nil {
+		return fmt.Errorf("GetGlobalParam2 error:%s", err)
+	}
+	for _, authorization := range authorizations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		item, ok := peerPoolMap.PeerPoolMap[authorization.PeerPubkey]
+		if !ok {
+			result.AddWarning(fmt.Sprintf("authorization to %s skipped, not in peer pool", authorization.PeerPubkey))
+			continue
+		}
+		if item.Status != governance.CandidateStatus && item.Status != governance.ConsensusStatus {
+			result.AddWarning(fmt.Sprintf("authorization to %s skipped, status %s can not be authorized",
+				authorization.PeerPubkey, statusName(item.Status)))
+			continue
+		}
+		pos := authorization.Pos
+		if pos < 0 {
+			pos = -pos
+		}
+		minPos := int64(globalParam2.MinAuthorizePos)
+		if pos == 0 || (minPos > 0 && pos%minPos != 0) {
+			result.AddWarning(fmt.Sprintf("authorization %d to %s skipped, pos must be times of %d",
+				authorization.Pos, authorization.PeerPubkey, minPos))
+			continue
+		}
+		if authorization.Pos < 0 {
+			if uint64(pos) > item.TotalPos {
+				result.AddWarning(fmt.Sprintf("unauthorization %d to %s skipped, total pos is %d",
+					pos, authorization.PeerPubkey, item.TotalPos))
+				continue
+			}
+			item.TotalPos -= uint64(pos)
+			continue
+		}
+		totalPos := item.TotalPos + uint64(pos)
+		if totalPos > uint64(globalParam.PosLimit)*item.InitPos {
+			result.AddWarning(fmt.Sprintf("authorization %d to %s skipped, total pos %d would be more than PosLimit %d times init pos",
+				pos, authorization.PeerPubkey, totalPos, globalParam.PosLimit))
+			continue
+		}
+		peerAttributes, err := gov.GetAttributes(authorization.PeerPubkey)
+		if err != nil {
+			return fmt.Errorf("GetAttributes error:%s", err)
+		}
+		if totalPos > peerAttributes.MaxAuthorize {
+			result.AddWarning(fmt.Sprintf("authorization %d to %s skipped, total pos %d would be more than MaxAuthorize %d",
+				pos, authorization.PeerPubkey, totalPos, peerAttributes.MaxAuthorize))
+			continue
+		}
+		item.TotalPos = totalPos
+		env.Logger.Info("Authorize %d to %s, total pos %d", pos, authorization.PeerPubkey, totalPos)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+func TestSimulateElection(t *testing.T) {
+	pool := newTestPool()
+	tests := []struct {
+		name  string
+		param *SimulateElectionParam
+		//changes map peer index to the change of the election
+		changes map[uint32]string
+		warning string
+		err     string
+	}{
+		{
+			name:    "pool keeps consensus",
+			param:   &SimulateElectionParam{},
+			changes: map[uint32]string{1: "", 7: "", 8: "", 10: "quits, leaves pool"},
+		},
+		{
+			name: "authorization enters consensus",
+			param: &SimulateElectionParam{Authorizations: []*SimulatedAuthorization{
+				{PeerPubkey: pool[8].PeerPubkey, Pos: 25000},
+			}},
+			changes: map[uint32]string{7: "leaves consensus", 8: "", 9: "enters consensus"},
+		},
+		{
+			name: "unauthorization leaves consensus",
+			param: &SimulateElectionParam{Authorizations: []*SimulatedAuthorization{
+				{PeerPubkey: pool[6].PeerPubkey, Pos: -10000},
+				{PeerPubkey: pool[7].PeerPubkey, Pos: 500},
+			}},
+			changes: map[uint32]string{7: "leaves consensus", 8: "enters consensus"},
+		},
+		{
+			name: "authorization not times of MinAuthorizePos",
+			param: &SimulateElectionParam{Authorizations: []*SimulatedAuthorization{
+				{PeerPubkey: pool[8].PeerPubkey, Pos: 100},
+			}},
+			changes: map[uint32]string{9: ""},
+			warning: "pos must be times of 500",
+		},
+		{
+			name: "authorization over MaxAuthorize",
+			param: &SimulateElectionParam{Authorizations: []*SimulatedAuthorization{
+				{PeerPubkey: pool[7].PeerPubkey, Pos: 40000},
+			}},
+			changes: map[uint32]string{8: ""},
+			warning: "total pos 50000 would be more than MaxAuthorize 40000",
+		},
+		{
+			name: "authorization to quiting peer",
+			param: &SimulateElectionParam{Authorizations: []*SimulatedAuthorization{
+				{PeerPubkey: pool[9].PeerPubkey, Pos: 500},
+			}},
+			changes: map[uint32]string{10: "quits, leaves pool"},
+			warning: "can not be authorized",
+		},
+		{
+			name:  "K more than candidates",
+			param: &SimulateElectionParam{K: 10},
+			err:   "9 candidate and consensus peers are less than K 10",
+		},
+		{
+			name:  "L less than twice K",
+			param: &SimulateElectionParam{L: 13},
+			err:   "L 13 must be at least twice K 7",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestChain(t, pool)
+			for _, item := range pool[7:9] {
+				err := chain.SetAttributes(&governance.PeerAttributes{PeerPubkey: item.PeerPubkey, MaxAuthorize: 40000})
+				if err != nil {
+					t.Fatalf("SetAttributes error:%s", err)
+				}
+			}
+			params, err := json.Marshal(test.param)
+			if err != nil {
+				t.Fatalf("json.Marshal error:%s", err)
+			}
+			result, err := SimulateElection(context.Background(), newTestEnv(chain, string(params)))
+			checkError(t, err, test.err)
+			if test.err != "" {
+				return
+			}
+			warnings := strings.Join(result.Warnings, "; ")
+			if test.warning == "" && warnings != "" || !strings.Contains(warnings, test.warning) {
+				t.Fatalf("warnings %q, should include %q", warnings, test.warning)
+			}
+
+			config := outputOf(result, "config").(*electionConfig)
+			peers := outputOf(result, "peers").([]*electedPeer)
+			if config.View != testView || config.K != 7 || config.L != 112 || config.Candidates != 9 || len(peers) != len(pool) {
+				t.Fatalf("config %+v with %d peers, should elect 7 of 9 candidates in view %d", config, len(peers), testView)
+			}
+			var consensus int
+			var posTableSize uint64
+			for _, peer := range peers {
+				if peer.NextStatus == statusName(governance.ConsensusStatus) {
+					consensus++
+				}
+				posTableSize += peer.PosSlots
+				if change, ok := test.changes[peer.Index]; ok && peer.Change != change {
+					t.Fatalf("peer %d changes %q, should change %q", peer.Index, peer.Change, change)
+				}
+			}
+			if consensus != 7 || posTableSize != config.PosTableSize {
+				t.Fatalf("%d peers elected with %d pos slots, should be 7 with %d", consensus, posTableSize, config.PosTableSize)
+			}
+		})
+	}
+}
+
+func TestSimulateElectionPosTable(t *testing.T) {
+	pool := make([]*governance.PeerPoolItem, 0, 7)
+	for i := uint32(1); i <= 7; i++ {
+		pool = append(pool, newTestPeer(i, governance.ConsensusStatus, 10000, 0))
+	}
+	tests := []struct {
+		name   string
+		params string
+		//slots of every peer, which stake the same
+		slots uint64
+	}{
+		{name: "L of config", params: `{}`, slots: 112/7 - 1},
+		{name: "L overridden", params: `{"L":70}`, slots: 70/7 - 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := SimulateElection(context.Background(), newTestEnv(newTestChain(t, pool), test.params))
+			checkError(t, err, "")
+			for _, peer := range outputOf(result, "peers").([]*electedPeer) {
+				if peer.PosSlots != test.slots || peer.PosPercent != 14.29 {
+					t.Fatalf("peer %d has %d pos slots %.2f%%, should have %d", peer.Index, peer.PosSlots, peer.PosPercent, test.slots)
+				}
+			}
+		})
+	}
+}
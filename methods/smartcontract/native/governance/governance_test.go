@@ -0,0 +1,392 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	log4 "github.com/alecthomas/log4go"
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/core"
+	"github.com/ontio/ontology-tool/fakechain"
+	ocommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//testView is the governance view of the test chain
+const testView = 2
+
+//testConfig return the vbft config of the test chain, the one of genesis
+func testConfig() *governance.Configuration {
+	return &governance.Configuration{
+		N:                    7,
+		C:                    2,
+		K:                    7,
+		L:                    112,
+		BlockMsgDelay:        10000,
+		HashMsgDelay:         10000,
+		PeerHandshakeTimeout: 10,
+		MaxBlockChangeView:   120000,
+	}
+}
+
+func testGlobalParam() *governance.GlobalParam {
+	return &governance.GlobalParam{
+		CandidateFee: 500000000000,
+		MinInitStake: 10000,
+		CandidateNum: 7 * 7,
+		PosLimit:     20,
+		A:            50,
+		B:            50,
+		Yita:         5,
+		Penalty:      5,
+	}
+}
+
+func testGlobalParam2() *governance.GlobalParam2 {
+	return &governance.GlobalParam2{
+		MinAuthorizePos:      500,
+		CandidateFeeSplitNum: 7 * 7,
+	}
+}
+
+//testSplitCurve return a split curve rising by 1000 at each point of Xi, so it is Xi/100
+func testSplitCurve() []uint32 {
+	yi := make([]uint32, len(governance.Xi))
+	for i := range yi {
+		yi[i] = uint32(i) * 1000
+	}
+	return yi
+}
+
+//newTestPeer return a peer pool item owned by a new account
+func newTestPeer(index uint32, status governance.Status, initPos, totalPos uint64) *governance.PeerPoolItem {
+	account := sdk.NewAccount()
+	return &governance.PeerPoolItem{
+		Index:      index,
+		PeerPubkey: hex.EncodeToString(keypair.SerializePublicKey(account.PublicKey)),
+		Address:    account.Address,
+		Status:     status,
+		InitPos:    initPos,
+		TotalPos:   totalPos,
+	}
+}
+
+//newTestPool return 7 consensus peers, 2 candidate peers and a quiting peer. The peer of index i stakes
+//110000-10000*i, so the pool is in stake order
+func newTestPool() []*governance.PeerPoolItem {
+	pool := make([]*governance.PeerPoolItem, 0, 10)
+	for i := uint32(1); i <= 10; i++ {
+		status := governance.ConsensusStatus
+		if i > 7 {
+			status = governance.CandidateStatus
+		}
+		if i == 10 {
+			status = governance.QuitingStatus
+		}
+		pool = append(pool, newTestPeer(i, status, uint64(100000-10000*i), 10000))
+	}
+	return pool
+}
+
+//newTestChain return a fake chain in view testView with the test governance params and pool as peer pool of the
+//current and previous view
+func newTestChain(t *testing.T, pool []*governance.PeerPoolItem) *fakechain.Chain {
+	chain := fakechain.New()
+	err := chain.SetGovernanceView(&governance.GovernanceView{View: testView, Height: 1})
+	if err != nil {
+		t.Fatalf("SetGovernanceView error:%s", err)
+	}
+	chain.SetVbftConfig(testConfig())
+	chain.SetGlobalParam(testGlobalParam())
+	err = chain.SetGlobalParam2(testGlobalParam2())
+	if err != nil {
+		t.Fatalf("SetGlobalParam2 error:%s", err)
+	}
+	err = chain.SetSplitCurve(&governance.SplitCurve{Yi: testSplitCurve()})
+	if err != nil {
+		t.Fatalf("SetSplitCurve error:%s", err)
+	}
+	peerPoolMap := &governance.PeerPoolMap{PeerPoolMap: make(map[string]*governance.PeerPoolItem)}
+	for _, item := range pool {
+		peerPoolMap.PeerPoolMap[item.PeerPubkey] = item
+	}
+	for _, view := range []uint32{testView - 1, testView} {
+		err = chain.SetPeerPoolMap(view, peerPoolMap)
+		if err != nil {
+			t.Fatalf("SetPeerPoolMap error:%s", err)
+		}
+	}
+	return chain
+}
+
+//newTestEnv return the env of a method run on chain with params in json
+func newTestEnv(chain *fakechain.Chain, params string) *core.Env {
+	return &core.Env{
+		Chain:  chain,
+		Config: config.DefConfig,
+		Params: &core.ParamSource{Data: json.RawMessage(params)},
+		Logger: make(log4.Logger),
+	}
+}
+
+//outputOf return the value of output name of result, nil if there is none
+func outputOf(result *core.Result, name string) interface{} {
+	for _, output := range result.Outputs {
+		if output.Name == name {
+			return output.Value
+		}
+	}
+	return nil
+}
+
+//checkError fail t if err does not contain want, or is not nil when want is empty
+func checkError(t *testing.T, err error, want string) {
+	t.Helper()
+	if want == "" && err != nil {
+		t.Fatalf("unexpected error:%s", err)
+	}
+	if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+		t.Fatalf("error %v, should contain %q", err, want)
+	}
+}
+
+func TestReaders(t *testing.T) {
+	pool := newTestPool()
+	chain := newTestChain(t, pool)
+	authorizer := sdk.NewAccount().Address
+	authorizeInfo := &governance.AuthorizeInfo{
+		PeerPubkey:   pool[0].PeerPubkey,
+		Address:      authorizer,
+		ConsensusPos: 1000,
+		NewPos:       500,
+	}
+	err := chain.SetAuthorizeInfo(authorizeInfo)
+	if err != nil {
+		t.Fatalf("SetAuthorizeInfo error:%s", err)
+	}
+	err = chain.SetBlackList(&governance.BlackListItem{PeerPubkey: pool[9].PeerPubkey, Address: pool[9].Address, InitPos: pool[9].InitPos})
+	if err != nil {
+		t.Fatalf("SetBlackList error:%s", err)
+	}
+	peerPoolMap := &governance.PeerPoolMap{PeerPoolMap: make(map[string]*governance.PeerPoolItem)}
+	for _, item := range pool {
+		peerPoolMap.PeerPoolMap[item.PeerPubkey] = item
+	}
+
+	tests := []struct {
+		name   string
+		method core.Method
+		params string
+		output string
+		want   interface{}
+		err    string
+	}{
+		{
+			name:   "vbft config",
+			method: GetVbftConfig,
+			output: "config",
+			want:   testConfig(),
+		},
+		{
+			name:   "peer pool map",
+			method: GetPeerPoolMap,
+			output: "peerPoolMap",
+			want:   peerPoolMap,
+		},
+		{
+			name:   "authorize info",
+			method: GetAuthorizeInfo,
+			params: fmt.Sprintf(`{"Address":"%s","PeerPubkey":"%s"}`, authorizer.ToBase58(), pool[0].PeerPubkey),
+			output: "authorizeInfo",
+			want:   authorizeInfo,
+		},
+		{
+			name:   "authorize info never authorized",
+			method: GetAuthorizeInfo,
+			params: fmt.Sprintf(`{"Address":"%s","PeerPubkey":"%s"}`, authorizer.ToBase58(), pool[1].PeerPubkey),
+			output: "authorizeInfo",
+			want:   &governance.AuthorizeInfo{PeerPubkey: pool[1].PeerPubkey, Address: authorizer},
+		},
+		{
+			name:   "authorize info of invalid address",
+			method: GetAuthorizeInfo,
+			params: fmt.Sprintf(`{"Address":"invalid","PeerPubkey":"%s"}`, pool[0].PeerPubkey),
+			err:    "AddressFromBase58 error",
+		},
+		{
+			name:   "in black list",
+			method: InBlackList,
+			params: fmt.Sprintf(`{"PeerPubkey":"%s"}`, pool[9].PeerPubkey),
+			output: "inBlackList",
+			want:   true,
+		},
+		{
+			name:   "not in black list",
+			method: InBlackList,
+			params: fmt.Sprintf(`{"PeerPubkey":"%s"}`, pool[0].PeerPubkey),
+			output: "inBlackList",
+			want:   false,
+		},
+		{
+			name:   "in black list of invalid peer public key",
+			method: InBlackList,
+			params: `{"PeerPubkey":"invalid"}`,
+			err:    "InBlackList error",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := test.params
+			if params == "" {
+				params = "{}"
+			}
+			result, err := test.method(context.Background(), newTestEnv(chain, params))
+			checkError(t, err, test.err)
+			if test.err != "" {
+				return
+			}
+			value := outputOf(result, test.output)
+			if !reflect.DeepEqual(value, test.want) {
+				t.Fatalf("output %s is %+v, should be %+v", test.output, value, test.want)
+			}
+		})
+	}
+}
+
+func TestReadersOfEmptyChain(t *testing.T) {
+	chain := fakechain.New()
+	tests := []struct {
+		name   string
+		method core.Method
+		err    string
+	}{
+		{name: "vbft config", method: GetVbftConfig, err: "GetVbftConfig error"},
+		{name: "peer pool map", method: GetPeerPoolMap, err: "GetPeerPoolMap error"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.method(context.Background(), newTestEnv(chain, "{}"))
+			checkError(t, err, test.err)
+		})
+	}
+}
+
+func TestTxBuilders(t *testing.T) {
+	pool := newTestPool()
+	peers := []string{pool[0].PeerPubkey, pool[1].PeerPubkey}
+	tests := []struct {
+		name   string
+		send   func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error)
+		method string
+	}{
+		{
+			name: "commit dpos",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.CommitDpos(signer)
+			},
+			method: "commitDpos",
+		},
+		{
+			name: "update config",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.UpdateConfig(signer, testConfig())
+			},
+			method: "updateConfig",
+		},
+		{
+			name: "update split curve",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.UpdateSplitCurve(signer, &governance.SplitCurve{Yi: testSplitCurve()})
+			},
+			method: "updateSplitCurve",
+		},
+		{
+			name: "authorize for peer",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.AuthorizeForPeer(signer, peers, []uint32{500, 1000})
+			},
+			method: "authorizeForPeer",
+		},
+		{
+			name: "unauthorize for peer",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.UnAuthorizeForPeer(signer, peers, []uint32{500, 1000})
+			},
+			method: "unAuthorizeForPeer",
+		},
+		{
+			name: "set fee percentage",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.SetFeePercentage(signer, peers[0], 50, 20)
+			},
+			method: "setFeePercentage",
+		},
+		{
+			name: "black node",
+			send: func(gov *client.GovernanceClient, signer *client.Signer) (ocommon.Uint256, error) {
+				return gov.BlackNode(signer, peers)
+			},
+			method: "blackNode",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestChain(t, pool)
+			account := sdk.NewAccount()
+			txHash, err := test.send(client.NewGovernanceClient(chain, nil), client.NewAccountSigner(account))
+			if err != nil {
+				t.Fatalf("send error:%s", err)
+			}
+			sent := chain.Sent()
+			if len(sent) != 1 {
+				t.Fatalf("%d transactions sent, should be 1", len(sent))
+			}
+			if sent[0].Contract != utils.GovernanceContractAddress || sent[0].Method != test.method {
+				t.Fatalf("sent %s %s, should be governance %s", sent[0].Contract.ToHexString(), sent[0].Method, test.method)
+			}
+			if sent[0].Tx.Payer != account.Address {
+				t.Fatalf("payer %s, should be signer %s", sent[0].Tx.Payer.ToBase58(), account.Address.ToBase58())
+			}
+			if hash := sent[0].Tx.Hash(); hash != txHash {
+				t.Fatalf("sent tx %s, returned hash %s", hash.ToHexString(), txHash.ToHexString())
+			}
+		})
+	}
+
+	t.Run("pre-execution failure", func(t *testing.T) {
+		chain := newTestChain(t, pool)
+		chain.SetFailure("commitDpos", fmt.Errorf("commitDpos failed"))
+		_, err := client.NewGovernanceClient(chain, nil).CommitDpos(client.NewAccountSigner(sdk.NewAccount()))
+		checkError(t, err, "commitDpos failed")
+		if len(chain.Sent()) != 0 {
+			t.Fatalf("failed transaction sent")
+		}
+	})
+}
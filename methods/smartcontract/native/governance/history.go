@@ -0,0 +1,325 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/core"
+	ocommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+type PeerPoolHistoryParam struct {
+	//FromView is the first view, default is the view before ToView
+	FromView uint32
+	//ToView is the last view, default is the current view
+	ToView uint32
+	//Archive is a json file of peer pools read before. The contract deletes old views, so views no longer on chain
+	//are taken from it, and closed views read from chain are added to it
+	Archive string
+}
+
+//peerPoolView tell where the peer pool of a view comes from and how many peers are in each state
+type peerPoolView struct {
+	View      uint32
+	Source    string
+	Peers     int
+	Consensus int
+	Candidate int
+}
+
+//peerPoolChange is a peer of a view which differs from the previous view read
+type peerPoolChange struct {
+	View       uint32
+	PeerPubkey string
+	Index      uint32
+	Status     string
+	InitPos    uint64
+	TotalPos   uint64
+	Change     string
+}
+
+//Sources of a view in the peer pool history
+const (
+	VIEW_SOURCE_CHAIN   = "chain"
+	VIEW_SOURCE_ARCHIVE = "archive"
+	VIEW_SOURCE_MISSING = "missing"
+)
+
+func PeerPoolHistory(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	param := new(PeerPoolHistoryParam)
+	err := env.Params.Load(param)
+	if err != nil {
+		return result, err
+	}
+	gov := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	currentView, err := gov.GetView()
+	if err != nil {
+		return result, fmt.Errorf("GetView error:%s", err)
+	}
+	if param.ToView == 0 {
+		param.ToView = currentView
+	}
+	if param.FromView == 0 && param.ToView > 1 {
+		param.FromView = param.ToView - 1
+	}
+	if param.FromView > param.ToView {
+		return result, fmt.Errorf("FromView %d is after ToView %d", param.FromView, param.ToView)
+	}
+	archive, err := loadPeerPoolArchive(param.Archive)
+	if err != nil {
+		return result, err
+	}
+
+	views := make([]*peerPoolView, 0)
+	changes := make([]*peerPoolChange, 0)
+	var prev *governance.PeerPoolMap
+	archived := 0
+	for v := uint64(param.FromView); v <= uint64(param.ToView); v++ {
+		view := uint32(v)
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		source := VIEW_SOURCE_CHAIN
+		peerPoolMap, err := gov.GetPeerPoolMapOfView(view)
+		if errors.Is(err, client.ErrViewNotFound) {
+			source = VIEW_SOURCE_ARCHIVE
+			peerPoolMap, err = archive.get(view)
+		} else if err == nil && param.Archive != "" && view < currentView {
+			//the pool of the current view changes until the next commitDpos, closed views do not
+			var added bool
+			added, err = archive.put(view, peerPoolMap)
+			if added {
+				archived++
+			}
+		}
+		if err != nil {
+			return result, fmt.Errorf("peer pool of view %d error:%s", view, err)
+		}
+		if peerPoolMap == nil {
+			views = append(views, &peerPoolView{View: view, Source: VIEW_SOURCE_MISSING})
+			result.AddWarning(fmt.Sprintf("peer pool of view %d is neither on chain nor in archive", view))
+			continue
+		}
+		views = append(views, summarizeView(view, source, peerPoolMap))
+		changes = append(changes, diffPeerPool(view, prev, peerPoolMap)...)
+		prev = peerPoolMap
+	}
+	if archived > 0 {
+		err = archive.save(param.Archive)
+		if err != nil {
+			return result, err
+		}
+		env.Logger.Info("%d views added to archive %s", archived, param.Archive)
+	}
+
+	result.AddOutput("views", views)
+	result.AddOutput("changes", changes)
+	return result, nil
+}
+
+//summarizeView count the peers of a view by state
+func summarizeView(view uint32, source string, peerPoolMap *governance.PeerPoolMap) *peerPoolView {
+	summary := &peerPoolView{View: view, Source: source, Peers: len(peerPoolMap.PeerPoolMap)}
+	for _, item := range peerPoolMap.PeerPoolMap {
+		switch item.Status {
+		case governance.ConsensusStatus:
+			summary.Consensus++
+		case governance.CandidateStatus:
+			summary.Candidate++
+		}
+	}
+	return summary
+}
+
+//diffPeerPool return the peers of view which joined, left or changed since prev, every peer if prev is nil.
+//Changes are in index order
+func diffPeerPool(view uint32, prev, cur *governance.PeerPoolMap) []*peerPoolChange {
+	changes := make([]*peerPoolChange, 0)
+	for peerPubkey, item := range cur.PeerPoolMap {
+		change := &peerPoolChange{
+			View:       view,
+			PeerPubkey: peerPubkey,
+			Index:      item.Index,
+			Status:     statusName(item.Status),
+			InitPos:    item.InitPos,
+			TotalPos:   item.TotalPos,
+		}
+		if prev == nil {
+			change.Change = "initial"
+			changes = append(changes, change)
+			continue
+		}
+		old, ok := prev.PeerPoolMap[peerPubkey]
+		if !ok {
+			old = new(governance.PeerPoolItem)
+		}
+		change.Change = describeChange(old, item, ok)
+		if change.Change != "" {
+			changes = append(changes, change)
+		}
+	}
+	if prev != nil {
+		for peerPubkey, old := range prev.PeerPoolMap {
+			if _, ok := cur.PeerPoolMap[peerPubkey]; ok {
+				continue
+			}
+			change := "left pool"
+			if old.Status == governance.ConsensusStatus {
+				change = "left consensus, left pool"
+			}
+			changes = append(changes, &peerPoolChange{
+				View:       view,
+				PeerPubkey: peerPubkey,
+				Index:      old.Index,
+				Change:     change,
+			})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Index < changes[j].Index
+	})
+	return changes
+}
+
+//describeChange return what changed from old to cur, empty if nothing. inPool is false if the peer was not in
+//the pool of the previous view
+func describeChange(old, cur *governance.PeerPoolItem, inPool bool) string {
+	var changes []string
+	if !inPool {
+		changes = append(changes, "joined pool")
+	}
+	if cur.Status == governance.ConsensusStatus && old.Status != governance.ConsensusStatus {
+		changes = append(changes, "entered consensus")
+	}
+	if old.Status == governance.ConsensusStatus && cur.Status != governance.ConsensusStatus {
+		changes = append(changes, "left consensus")
+	}
+	if inPool && old.Status != cur.Status {
+		changes = append(changes, fmt.Sprintf("status %s->%s", statusName(old.Status), statusName(cur.Status)))
+	}
+	if inPool && old.InitPos != cur.InitPos {
+		changes = append(changes, fmt.Sprintf("InitPos %+d", int64(cur.InitPos)-int64(old.InitPos)))
+	}
+	if inPool && old.TotalPos != cur.TotalPos {
+		changes = append(changes, fmt.Sprintf("TotalPos %+d", int64(cur.TotalPos)-int64(old.TotalPos)))
+	}
+	return strings.Join(changes, ", ")
+}
+
+//statusName return the name of a peer status of the governance contract
+func statusName(status governance.Status) string {
+	switch status {
+	case governance.RegisterCandidateStatus:
+		return "RegisterCandidate"
+	case governance.CandidateStatus:
+		return "Candidate"
+	case governance.ConsensusStatus:
+		return "Consensus"
+	case governance.QuitConsensusStatus:
+		return "QuitConsensus"
+	case governance.QuitingStatus:
+		return "Quiting"
+	case governance.BlackStatus:
+		return "Black"
+	}
+	return fmt.Sprintf("Unknown(%d)", status)
+}
+
+//peerPoolArchive is the hex encoded peer pools by view kept in an archive file
+type peerPoolArchive map[string]string
+
+//loadPeerPoolArchive read the archive of path, empty if path is empty or the file does not exist yet
+func loadPeerPoolArchive(path string) (peerPoolArchive, error) {
+	archive := make(peerPoolArchive)
+	if path == "" {
+		return archive, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return archive, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read archive error:%s", err)
+	}
+	err = json.Unmarshal(data, &archive)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal archive %s error:%s", path, err)
+	}
+	return archive, nil
+}
+
+//get return the peer pool of view, nil if not archived
+func (this peerPoolArchive) get(view uint32) (*governance.PeerPoolMap, error) {
+	value, ok := this[strconv.FormatUint(uint64(view), 10)]
+	if !ok {
+		return nil, nil
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString archived view %d error:%s", view, err)
+	}
+	peerPoolMap := &governance.PeerPoolMap{
+		PeerPoolMap: make(map[string]*governance.PeerPoolItem),
+	}
+	err = peerPoolMap.Deserialization(ocommon.NewZeroCopySource(data))
+	if err != nil {
+		return nil, fmt.Errorf("deserialize archived view %d error:%s", view, err)
+	}
+	return peerPoolMap, nil
+}
+
+//put archive the peer pool of view, return true if it was not archived or archived with other values
+func (this peerPoolArchive) put(view uint32, peerPoolMap *governance.PeerPoolMap) (bool, error) {
+	sink := ocommon.NewZeroCopySink(nil)
+	err := peerPoolMap.Serialization(sink)
+	if err != nil {
+		return false, fmt.Errorf("serialize view %d error:%s", view, err)
+	}
+	key, value := strconv.FormatUint(uint64(view), 10), hex.EncodeToString(sink.Bytes())
+	if this[key] == value {
+		return false, nil
+	}
+	this[key] = value
+	return true, nil
+}
+
+func (this peerPoolArchive) save(path string) error {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Marshal archive error:%s", err)
+	}
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("write archive error:%s", err)
+	}
+	return nil
+}
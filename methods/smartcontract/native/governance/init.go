@@ -23,62 +23,420 @@ import (
 )
 
 func RegisterGovernance() {
-	core.OntTool.RegMethod("RegIdWithPublicKey", RegIdWithPublicKey)
-	core.OntTool.RegMethod("AssignFuncsToRole", AssignFuncsToRole)
-	core.OntTool.RegMethod("AssignFuncsToRoleAny", AssignFuncsToRoleAny)
-	core.OntTool.RegMethod("AssignOntIDsToRole", AssignOntIDsToRole)
-	core.OntTool.RegMethod("AssignOntIDsToRoleAny", AssignOntIDsToRoleAny)
-	core.OntTool.RegMethod("RegisterCandidate", RegisterCandidate)
-	core.OntTool.RegMethod("RegisterCandidate2Sign", RegisterCandidate2Sign)
-	core.OntTool.RegMethod("UnRegisterCandidate", UnRegisterCandidate)
-	core.OntTool.RegMethod("ApproveCandidate", ApproveCandidate)
-	core.OntTool.RegMethod("RejectCandidate", RejectCandidate)
-	core.OntTool.RegMethod("ChangeMaxAuthorization", ChangeMaxAuthorization)
-	core.OntTool.RegMethod("SetFeePercentage", SetFeePercentage)
-	core.OntTool.RegMethod("AddInitPos", AddInitPos)
-	core.OntTool.RegMethod("ReduceInitPos", ReduceInitPos)
-	core.OntTool.RegMethod("AuthorizeForPeer", AuthorizeForPeer)
-	core.OntTool.RegMethod("UnAuthorizeForPeer", UnAuthorizeForPeer)
-	core.OntTool.RegMethod("Withdraw", Withdraw)
-	core.OntTool.RegMethod("QuitNode", QuitNode)
-	core.OntTool.RegMethod("BlackNode", BlackNode)
-	core.OntTool.RegMethod("WhiteNode", WhiteNode)
-	core.OntTool.RegMethod("CommitDpos", CommitDpos)
-	core.OntTool.RegMethod("UpdateConfig", UpdateConfig)
-	core.OntTool.RegMethod("UpdateGlobalParam", UpdateGlobalParam)
-	core.OntTool.RegMethod("UpdateGlobalParam2", UpdateGlobalParam2)
-	core.OntTool.RegMethod("UpdateSplitCurve", UpdateSplitCurve)
-	core.OntTool.RegMethod("TransferPenalty", TransferPenalty)
-	core.OntTool.RegMethod("SetPromisePos", SetPromisePos)
-	core.OntTool.RegMethod("GetVbftConfig", GetVbftConfig)
-	core.OntTool.RegMethod("GetPreConfig", GetPreConfig)
-	core.OntTool.RegMethod("GetGlobalParam", GetGlobalParam)
-	core.OntTool.RegMethod("GetGlobalParam2", GetGlobalParam2)
-	core.OntTool.RegMethod("GetSplitCurve", GetSplitCurve)
-	core.OntTool.RegMethod("GetGovernanceView", GetGovernanceView)
-	core.OntTool.RegMethod("GetPeerPoolItem", GetPeerPoolItem)
-	core.OntTool.RegMethod("GetPeerPoolMap", GetPeerPoolMap)
-	core.OntTool.RegMethod("GetAuthorizeInfo", GetAuthorizeInfo)
-	core.OntTool.RegMethod("GetTotalStake", GetTotalStake)
-	core.OntTool.RegMethod("GetPenaltyStake", GetPenaltyStake)
-	core.OntTool.RegMethod("GetAttributes", GetAttributes)
-	core.OntTool.RegMethod("GetSplitFee", GetSplitFee)
-	core.OntTool.RegMethod("GetSplitFeeAddress", GetSplitFeeAddress)
-	core.OntTool.RegMethod("GetPromisePos", GetPromisePos)
-	core.OntTool.RegMethod("InBlackList", InBlackList)
-	core.OntTool.RegMethod("WithdrawOng", WithdrawOng)
-	core.OntTool.RegMethod("Vrf", Vrf)
-	core.OntTool.RegMethod("MultiTransferOnt", MultiTransferOnt)
-	core.OntTool.RegMethod("MultiTransferOng", MultiTransferOng)
-	core.OntTool.RegMethod("TransferOntMultiSign", TransferOntMultiSign)
-	core.OntTool.RegMethod("TransferOngMultiSign", TransferOngMultiSign)
-	core.OntTool.RegMethod("TransferFromOngMultiSign", TransferFromOngMultiSign)
-	core.OntTool.RegMethod("TransferOntMultiSignAddress", TransferOntMultiSignAddress)
-	core.OntTool.RegMethod("TransferOngMultiSignAddress", TransferOngMultiSignAddress)
-	core.OntTool.RegMethod("TransferFromOngMultiSignAddress", TransferFromOngMultiSignAddress)
-	core.OntTool.RegMethod("GetAddressMultiSign", GetAddressMultiSign)
-	core.OntTool.RegMethod("TransferOntMultiSignToMultiSign", TransferOntMultiSignToMultiSign)
-	core.OntTool.RegMethod("TransferOngMultiSignToMultiSign", TransferOngMultiSignToMultiSign)
-	core.OntTool.RegMethod("TransferFromOngMultiSignToMultiSign", TransferFromOngMultiSignToMultiSign)
-	core.OntTool.RegMethod("GetVbftInfo", GetVbftInfo)
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "RegIdWithPublicKey",
+		Method:      RegIdWithPublicKey,
+		Description: "Register ONT ID of wallet default account with its public key",
+		Params:      new(Account),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AssignFuncsToRole",
+		Method:      AssignFuncsToRole,
+		Description: "Assign registerCandidate of governance contract to role TrionesCandidatePeerOwner",
+		Params:      new(Account),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AssignFuncsToRoleAny",
+		Method:      AssignFuncsToRoleAny,
+		Description: "Assign a function of any contract to a role",
+		Params:      new(AssignFuncsToRoleAnyParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AssignOntIDsToRole",
+		Method:      AssignOntIDsToRole,
+		Description: "Assign ONT IDs to role TrionesCandidatePeerOwner of governance contract",
+		Params:      new(AssignOntIDsToRoleParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AssignOntIDsToRoleAny",
+		Method:      AssignOntIDsToRoleAny,
+		Description: "Assign ONT IDs to a role of any contract",
+		Params:      new(AssignOntIDsToRoleAnyParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "RegisterCandidate",
+		Method:      RegisterCandidate,
+		Description: "Register as candidate node",
+		Params:      new(RegisterCandidateParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "RegisterCandidate2Sign",
+		Method:      RegisterCandidate2Sign,
+		Description: "Register as candidate node, signed by an encrypted key and a wallet",
+		Params:      new(RegisterCandidate2SignParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UnRegisterCandidate",
+		Method:      UnRegisterCandidate,
+		Description: "Cancel candidate node registration",
+		Params:      new(UnRegisterCandidateParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "ApproveCandidate",
+		Method:      ApproveCandidate,
+		Description: "Approve candidate nodes",
+		Params:      new(ApproveCandidateParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "RejectCandidate",
+		Method:      RejectCandidate,
+		Description: "Reject a candidate node",
+		Params:      new(RejectCandidateParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "ChangeMaxAuthorization",
+		Method:      ChangeMaxAuthorization,
+		Description: "Change max authorization a node accepts",
+		Params:      new(ChangeMaxAuthorizationParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "SetFeePercentage",
+		Method:      SetFeePercentage,
+		Description: "Set fee split percentage of init pos part and stake part of nodes",
+		Params:      new(SetFeePercentageParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AddInitPos",
+		Method:      AddInitPos,
+		Description: "Add init pos of a node",
+		Params:      new(AddInitPosParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "ReduceInitPos",
+		Method:      ReduceInitPos,
+		Description: "Reduce init pos of a node",
+		Params:      new(ReduceInitPosParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "AuthorizeForPeer",
+		Method:      AuthorizeForPeer,
+		Description: "Authorize stake to nodes",
+		Params:      new(AuthorizeForPeerParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UnAuthorizeForPeer",
+		Method:      UnAuthorizeForPeer,
+		Description: "Cancel stake authorized to nodes",
+		Params:      new(AuthorizeForPeerParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "Withdraw",
+		Method:      Withdraw,
+		Description: "Withdraw unfrozen stake ont",
+		Params:      new(WithdrawParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "QuitNode",
+		Method:      QuitNode,
+		Description: "Quit nodes",
+		Params:      new(QuitNodeParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "BlackNode",
+		Method:      BlackNode,
+		Description: "Put nodes into black list",
+		Params:      new(BlackNodeParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "WhiteNode",
+		Method:      WhiteNode,
+		Description: "Remove a node from black list",
+		Params:      new(WhiteNodeParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "CommitDpos",
+		Method:      CommitDpos,
+		Description: "Force switch to next consensus round",
+		Params:      new(MultiAccount),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UpdateConfig",
+		Method:      UpdateConfig,
+		Description: "Update consensus config",
+		Params:      new(UpdateConfigParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UpdateGlobalParam",
+		Method:      UpdateGlobalParam,
+		Description: "Update global param",
+		Params:      new(UpdateGlobalParamParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UpdateGlobalParam2",
+		Method:      UpdateGlobalParam2,
+		Description: "Update global param2",
+		Params:      new(UpdateGlobalParamParam2),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "UpdateSplitCurve",
+		Method:      UpdateSplitCurve,
+		Description: "Update fee split curve",
+		Params:      new(UpdateSplitCurveParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferPenalty",
+		Method:      TransferPenalty,
+		Description: "Transfer penalty ont of a black listed node",
+		Params:      new(TransferPenaltyParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "SetPromisePos",
+		Method:      SetPromisePos,
+		Description: "Set promise pos of nodes",
+		Params:      new(SetPromisePosParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetVbftConfig",
+		Method:      GetVbftConfig,
+		Description: "Get current consensus config",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetPreConfig",
+		Method:      GetPreConfig,
+		Description: "Get consensus config effective next round",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetGlobalParam",
+		Method:      GetGlobalParam,
+		Description: "Get global param",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetGlobalParam2",
+		Method:      GetGlobalParam2,
+		Description: "Get global param2",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetSplitCurve",
+		Method:      GetSplitCurve,
+		Description: "Get fee split curve",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetGovernanceView",
+		Method:      GetGovernanceView,
+		Description: "Get current governance view",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetPeerPoolItem",
+		Method:      GetPeerPoolItem,
+		Description: "Get info of a node",
+		Params:      new(GetPeerPoolItemParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetPeerPoolMap",
+		Method:      GetPeerPoolMap,
+		Description: "Get info of all nodes",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "PeerPoolHistory",
+		Method:      PeerPoolHistory,
+		Description: "Get changes of nodes across a range of views",
+		Params:      new(PeerPoolHistoryParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "SimulateElection",
+		Method:      SimulateElection,
+		Description: "Preview consensus nodes and pos table of next commitDpos",
+		Params:      new(SimulateElectionParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "RewardForecast",
+		Method:      RewardForecast,
+		Description: "Estimate ong of nodes and an address in a round and its annual yield",
+		Params:      new(RewardForecastParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetAuthorizeInfo",
+		Method:      GetAuthorizeInfo,
+		Description: "Get stake an address authorized to a node",
+		Params:      new(GetAuthorizeInfoParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetTotalStake",
+		Method:      GetTotalStake,
+		Description: "Get total stake of an address",
+		Params:      new(GetTotalStakeParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetPenaltyStake",
+		Method:      GetPenaltyStake,
+		Description: "Get penalty stake of a node",
+		Params:      new(GetPenaltyStakeParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetAttributes",
+		Method:      GetAttributes,
+		Description: "Get attributes of a node",
+		Params:      new(GetAttributesParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetSplitFee",
+		Method:      GetSplitFee,
+		Description: "Get total ong split but not withdrawn",
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetSplitFeeAddress",
+		Method:      GetSplitFeeAddress,
+		Description: "Get ong split to an address but not withdrawn",
+		Params:      new(GetSplitFeeAddressParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetPromisePos",
+		Method:      GetPromisePos,
+		Description: "Get promise pos of a node",
+		Params:      new(GetPromisePosParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "InBlackList",
+		Method:      InBlackList,
+		Description: "Check whether a node is in black list",
+		Params:      new(InBlackListParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "WithdrawOng",
+		Method:      WithdrawOng,
+		Description: "Withdraw ong fee",
+		Params:      new(WithdrawOngParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "Vrf",
+		Method:      Vrf,
+		Description: "Compute and verify vrf of wallet default account",
+		Params:      new(VrfParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "MultiTransferOnt",
+		Method:      MultiTransferOnt,
+		Description: "Transfer ont from several accounts in one transaction",
+		Params:      new(MultiTransferParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "MultiTransferOng",
+		Method:      MultiTransferOng,
+		Description: "Transfer ong from several accounts in one transaction",
+		Params:      new(MultiTransferParam),
+		SendTx:      true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOntMultiSign",
+		Method:      TransferOntMultiSign,
+		Description: "Transfer ont from multi-sign address to accounts",
+		Params:      new(TransferMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOngMultiSign",
+		Method:      TransferOngMultiSign,
+		Description: "Transfer ong from multi-sign address to accounts",
+		Params:      new(TransferMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferFromOngMultiSign",
+		Method:      TransferFromOngMultiSign,
+		Description: "TransferFrom ong of multi-sign address to accounts",
+		Params:      new(TransferFromMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOntMultiSignAddress",
+		Method:      TransferOntMultiSignAddress,
+		Description: "Transfer ont from multi-sign address to addresses",
+		Params:      new(TransferMultiSignAddressParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOngMultiSignAddress",
+		Method:      TransferOngMultiSignAddress,
+		Description: "Transfer ong from multi-sign address to addresses",
+		Params:      new(TransferMultiSignAddressParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferFromOngMultiSignAddress",
+		Method:      TransferFromOngMultiSignAddress,
+		Description: "TransferFrom ong of multi-sign address to addresses",
+		Params:      new(TransferFromMultiSignAddressParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetAddressMultiSign",
+		Method:      GetAddressMultiSign,
+		Description: "Compute multi-sign address of public keys",
+		Params:      new(GetAddressMultiSignParam),
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOntMultiSignToMultiSign",
+		Method:      TransferOntMultiSignToMultiSign,
+		Description: "Transfer ont from multi-sign address to multi-sign address",
+		Params:      new(TransferMultiSignToMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferOngMultiSignToMultiSign",
+		Method:      TransferOngMultiSignToMultiSign,
+		Description: "Transfer ong from multi-sign address to multi-sign address",
+		Params:      new(TransferMultiSignToMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "TransferFromOngMultiSignToMultiSign",
+		Method:      TransferFromOngMultiSignToMultiSign,
+		Description: "TransferFrom ong of multi-sign address to multi-sign address",
+		Params:      new(TransferFromMultiSignToMultiSignParam),
+		SendTx:      true,
+		MultiSign:   true,
+	})
+	core.OntTool.RegMethod(&core.MethodInfo{
+		Name:        "GetVbftInfo",
+		Method:      GetVbftInfo,
+		Description: "Get vbft chain config of latest block",
+	})
 }
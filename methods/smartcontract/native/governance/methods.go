@@ -19,80 +19,228 @@
 package governance
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math"
 	"time"
 
-	log4 "github.com/alecthomas/log4go"
 	"github.com/ontio/ontology-crypto/keypair"
 	s "github.com/ontio/ontology-crypto/signature"
 	"github.com/ontio/ontology-crypto/vrf"
 	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/client"
 	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/core"
 	ocommon "github.com/ontio/ontology/common"
-	"github.com/ontio/ontology/common/password"
 	"github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/smartcontract/service/native/governance"
 	"github.com/ontio/ontology/smartcontract/service/native/utils"
 )
 
+//MultiSignParam select the members of the multi-sign address signing a method, embedded in its params
+type MultiSignParam struct {
+	//Signers is the name of a signer group of config, instead of the members in params
+	Signers string
+	//M of the multi-sign address, default is (5n+6)/7
+	M uint16
+	//ExpectedAddress is checked against the derived multi-sign address if given
+	ExpectedAddress string
+}
+
+//ConfirmParam skip the question before a governance parameter update is sent, embedded in its params
+type ConfirmParam struct {
+	//Confirmed skips typing yes to the diff against the live values, for scripted runs
+	Confirmed bool
+}
+
 type Account struct {
 	Path string
 }
 
-func RegIdWithPublicKey(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RegIdWithPublicKey.json")
+//getAccounts open every wallet of paths, return accounts and their public keys
+func getAccounts(ctx context.Context, ontSdk *sdk.OntologySdk, paths []string) ([]*sdk.Account, []keypair.PublicKey, error) {
+	var users []*sdk.Account
+	var pubKeys []keypair.PublicKey
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, user)
+		pubKeys = append(pubKeys, user.PublicKey)
+	}
+	return users, pubKeys, nil
+}
+
+//getMultiSigners return the signer of a multi-sign method. Members are the signer group of config if group is
+//set, otherwise the wallets of paths, or pubKeys if given with paths as a subset of signers. The derived address
+//is logged, checked against expected and, if checkAdmin, against the governance admin before any wallet is
+//unlocked. When exporting an unsigned tx no password is asked
+func getMultiSigners(ctx context.Context, env *core.Env, group string, paths []string, pubKeys []string, m uint16,
+	expected string, checkAdmin bool) (*client.Signer, error) {
+	members, err := getMultiSignMembers(env, group, paths, pubKeys, m, expected)
 	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
+		return nil, err
 	}
-	account := new(Account)
-	err = json.Unmarshal(data, account)
+	env.Logger.Info("%s: %d-of-%d address %s", members.name, members.m, len(members.pubKeys), members.address.ToBase58())
+	if checkAdmin {
+		admin, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetGovernanceAdmin()
+		if err != nil {
+			return nil, fmt.Errorf("GetGovernanceAdmin error:%s", err)
+		}
+		if admin != members.address {
+			return nil, fmt.Errorf("%s address %s is not governance admin %s", members.name, members.address.ToBase58(), admin.ToBase58())
+		}
+	}
+	if env.Config.Export != "" {
+		return client.NewMultiSigner(members.pubKeys, members.m, nil), nil
+	}
+	if len(members.paths) == 0 {
+		return nil, fmt.Errorf("%s has no signer wallet", members.name)
+	}
+	users, _, err := getAccounts(ctx, env.Sdk, members.paths)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return nil, err
 	}
-	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, account.Path)
-	if !ok {
-		return false
+	return client.NewMultiSigner(members.pubKeys, members.m, users), nil
+}
+
+//multiSignMembers are the members of a multi-sign address and the wallets they sign with
+type multiSignMembers struct {
+	//name of the members in logs and errors
+	name    string
+	paths   []string
+	pubKeys []keypair.PublicKey
+	m       uint16
+	address ocommon.Address
+}
+
+//getMultiSignMembers return the members of a multi-sign address: the signer group of config if group is set,
+//otherwise pubKeys if given, or the public keys of the wallets of paths. The derived address is checked against
+//expected, no password is asked
+func getMultiSignMembers(env *core.Env, group string, paths []string, pubKeys []string, m uint16, expected string) (*multiSignMembers, error) {
+	name := "multi-sign"
+	if group != "" {
+		signerGroup, ok := env.Config.Signers[group]
+		if !ok {
+			return nil, fmt.Errorf("signer group %s not found in config", group)
+		}
+		if len(paths) > 0 || len(pubKeys) > 0 {
+			return nil, fmt.Errorf("signer group %s can not be used with paths or public keys in params", group)
+		}
+		name = fmt.Sprintf("signer group %s", group)
+		paths, pubKeys = signerGroup.Path, signerGroup.PubKeys
+		if m == 0 {
+			m = signerGroup.M
+		}
+		if expected == "" {
+			expected = signerGroup.ExpectedAddress
+		}
 	}
-	ok = regIdWithPublicKey(ontSdk, user)
-	if !ok {
-		return false
+	var members []keypair.PublicKey
+	var err error
+	if len(pubKeys) > 0 {
+		members, err = parsePubKeys(pubKeys)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, path := range paths {
+			pubKey, err := common.GetPubKeyByWallet(env.Sdk, path)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, pubKey)
+		}
+	}
+	m, address, err := common.MultiSignAddress(members, m, expected)
+	if err != nil {
+		return nil, fmt.Errorf("%s error:%s", name, err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return &multiSignMembers{
+		name:    name,
+		paths:   paths,
+		pubKeys: members,
+		m:       m,
+		address: address,
+	}, nil
 }
 
-func AssignFuncsToRole(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignFuncsToRole.json")
+//parseAddresses decode base58 addresses
+func parseAddresses(addresses []string) ([]ocommon.Address, error) {
+	var addrs []ocommon.Address
+	for _, address := range addresses {
+		addr, err := ocommon.AddressFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("common.AddressFromBase58 %s error:%s", address, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+//parsePubKeys deserialize hex encoded public keys
+func parsePubKeys(pubKeys []string) ([]keypair.PublicKey, error) {
+	var keys []keypair.PublicKey
+	for _, v := range pubKeys {
+		vByte, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("hex.DecodeString %s error:%s", v, err)
+		}
+		k, err := keypair.DeserializePublicKey(vByte)
+		if err != nil {
+			return nil, fmt.Errorf("keypair.DeserializePublicKey %s error:%s", v, err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func RegIdWithPublicKey(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	account := new(Account)
+	err := env.Params.Load(account)
 	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
+		return result, err
 	}
+	time.Sleep(1 * time.Second)
+	user, err := common.GetAccountByPassword(env.Sdk, account.Path)
+	if err != nil {
+		return result, err
+	}
+	txHash, err := client.NewOntIdClient(env.Chain, env.ClientOptions()).RegIDWithPublicKey(user)
+	if err != nil {
+		return result, fmt.Errorf("RegIdWithPublicKey error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	return result, nil
+}
+
+func AssignFuncsToRole(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	account := new(Account)
-	err = json.Unmarshal(data, account)
+	err := env.Params.Load(account)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, account.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, account.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = assignFuncsToRole(ontSdk, user, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", "registerCandidate")
-	if !ok {
-		return false
+	txHash, err := client.NewAuthClient(env.Chain, env.ClientOptions()).AssignFuncsToRole(user, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", []string{"registerCandidate"})
+	if err != nil {
+		return result, fmt.Errorf("AssignFuncsToRole error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type AssignFuncsToRoleAnyParam struct {
@@ -102,34 +250,28 @@ type AssignFuncsToRoleAnyParam struct {
 	Function        string
 }
 
-func AssignFuncsToRoleAny(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignFuncsToRoleAny.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignFuncsToRoleAny(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	assignFuncsToRoleAnyParam := new(AssignFuncsToRoleAnyParam)
-	err = json.Unmarshal(data, assignFuncsToRoleAnyParam)
+	err := env.Params.Load(assignFuncsToRoleAnyParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, assignFuncsToRoleAnyParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, assignFuncsToRoleAnyParam.Path)
+	if err != nil {
+		return result, err
 	}
 	contractAddress, err := common.GetAddressByHexString(assignFuncsToRoleAnyParam.ContractAddress)
 	if err != nil {
-		log4.Error("getAddressByHexString failed ", err)
-		return false
+		return result, fmt.Errorf("getAddressByHexString error:%s", err)
 	}
-	ok = assignFuncsToRole(ontSdk, user, contractAddress, assignFuncsToRoleAnyParam.Role, assignFuncsToRoleAnyParam.Function)
-	if !ok {
-		return false
+	txHash, err := client.NewAuthClient(env.Chain, env.ClientOptions()).AssignFuncsToRole(user, contractAddress, assignFuncsToRoleAnyParam.Role, []string{assignFuncsToRoleAnyParam.Function})
+	if err != nil {
+		return result, fmt.Errorf("AssignFuncsToRole error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type AssignOntIDsToRoleParam struct {
@@ -137,29 +279,24 @@ type AssignOntIDsToRoleParam struct {
 	Ontid []string
 }
 
-func AssignOntIDsToRole(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignOntIDsToRole.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignOntIDsToRole(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	assignOntIDsToRoleParam := new(AssignOntIDsToRoleParam)
-	err = json.Unmarshal(data, assignOntIDsToRoleParam)
+	err := env.Params.Load(assignOntIDsToRoleParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user1, ok := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleParam.Path1)
-	if !ok {
-		return false
+	user1, err := common.GetAccountByPassword(env.Sdk, assignOntIDsToRoleParam.Path1)
+	if err != nil {
+		return result, err
 	}
-	ok = assignOntIDsToRole(ontSdk, user1, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", assignOntIDsToRoleParam.Ontid)
-	if !ok {
-		return false
+	txHash, err := client.NewAuthClient(env.Chain, env.ClientOptions()).AssignOntIDsToRole(user1, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", assignOntIDsToRoleParam.Ontid)
+	if err != nil {
+		return result, fmt.Errorf("AssignOntIDsToRole error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type AssignOntIDsToRoleAnyParam struct {
@@ -169,34 +306,28 @@ type AssignOntIDsToRoleAnyParam struct {
 	Ontid           []string
 }
 
-func AssignOntIDsToRoleAny(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignOntIDsToRoleAny.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignOntIDsToRoleAny(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	assignOntIDsToRoleAnyParam := new(AssignOntIDsToRoleAnyParam)
-	err = json.Unmarshal(data, assignOntIDsToRoleAnyParam)
+	err := env.Params.Load(assignOntIDsToRoleAnyParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user1, ok := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleAnyParam.Path1)
-	if !ok {
-		return false
+	user1, err := common.GetAccountByPassword(env.Sdk, assignOntIDsToRoleAnyParam.Path1)
+	if err != nil {
+		return result, err
 	}
 	contractAddress, err := common.GetAddressByHexString(assignOntIDsToRoleAnyParam.ContractAddress)
 	if err != nil {
-		log4.Error("getAddressByHexString failed ", err)
-		return false
+		return result, fmt.Errorf("getAddressByHexString error:%s", err)
 	}
-	ok = assignOntIDsToRole(ontSdk, user1, contractAddress, assignOntIDsToRoleAnyParam.Role, assignOntIDsToRoleAnyParam.Ontid)
-	if !ok {
-		return false
+	txHash, err := client.NewAuthClient(env.Chain, env.ClientOptions()).AssignOntIDsToRole(user1, contractAddress, assignOntIDsToRoleAnyParam.Role, assignOntIDsToRoleAnyParam.Ontid)
+	if err != nil {
+		return result, fmt.Errorf("AssignOntIDsToRole error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type RegisterCandidateParam struct {
@@ -208,31 +339,29 @@ type RegisterCandidateParam struct {
 	OntIdPath  []string
 }
 
-func RegisterCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RegisterCandidate.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func RegisterCandidate(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	registerCandidateParam := new(RegisterCandidateParam)
-	err = json.Unmarshal(data, registerCandidateParam)
+	err := env.Params.Load(registerCandidateParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for i := 0; i < len(registerCandidateParam.PeerPubkey); i++ {
-		user, ok := common.GetAccountByPassword(ontSdk, registerCandidateParam.Path[i])
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = registerCandidate(ontSdk, user, registerCandidateParam.PeerPubkey[i], registerCandidateParam.InitPos[i])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(env.Sdk, registerCandidateParam.Path[i])
+		if err != nil {
+			return result, err
+		}
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).RegisterCandidate(client.NewAccountSigner(user), registerCandidateParam.PeerPubkey[i], registerCandidateParam.InitPos[i])
+		if err != nil {
+			return result, fmt.Errorf("RegisterCandidate %s error:%s", registerCandidateParam.PeerPubkey[i], err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type RegisterCandidate2SignParam struct {
@@ -244,21 +373,16 @@ type RegisterCandidate2SignParam struct {
 	InitPos    uint32
 }
 
-func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
+func RegisterCandidate2Sign(ctx context.Context, env *core.Env) (*core.Result, error) {
 	//"+UADcReBcLq0pn/2Grmz+UJsKl3ryop8pgRVHbQVgTBfT0lho06Svh4eQLSmC93j"
 	//"AG9W6c7nNhaiywcyVPgW9hQKvUYQr5iLvk"
 	//"IfxFV0Fer5LknIyCLP2P2w==2"
 
-	data, err := ioutil.ReadFile("./params/RegisterCandidate2Sign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+	result := core.NewResult()
 	registerCandidate2SignParam := new(RegisterCandidate2SignParam)
-	err = json.Unmarshal(data, registerCandidate2SignParam)
+	err := env.Params.Load(registerCandidate2SignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 
 	key, _ := base64.StdEncoding.DecodeString(registerCandidate2SignParam.Key)
@@ -274,10 +398,9 @@ func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
 	res.Param["curve"] = "P-256"
 
 	time.Sleep(1 * time.Second)
-	pwd, err := password.GetPassword()
+	pwd, err := common.GetPassword(registerCandidate2SignParam.Address)
 	if err != nil {
-		log4.Error("getPassword error:%s", err)
-		return false
+		return result, fmt.Errorf("getPassword error:%s", err)
 	}
 	pri, err := keypair.DecryptWithCustomScrypt(&res, pwd, &keypair.ScryptParam{
 		N:     4096,
@@ -287,8 +410,7 @@ func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
 	})
 	//pri, err := keypair.DecryptPrivateKey(&res, pwd)
 	if err != nil {
-		log4.Error("error: ", err)
-		return false
+		return result, fmt.Errorf("keypair.DecryptWithCustomScrypt error:%s", err)
 	}
 	address, _ := ocommon.AddressFromBase58(registerCandidate2SignParam.Address)
 	account := &sdk.Account{
@@ -297,15 +419,16 @@ func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
 		Address:    address,
 		SigScheme:  s.SHA256withECDSA,
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, registerCandidate2SignParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, registerCandidate2SignParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = registerCandidate2Sign(ontSdk, account, user, registerCandidate2SignParam.PeerPubkey, registerCandidate2SignParam.InitPos)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).RegisterCandidateWithOntId(account, user, registerCandidate2SignParam.PeerPubkey, registerCandidate2SignParam.InitPos)
+	if err != nil {
+		return result, fmt.Errorf("RegisterCandidateWithOntId error:%s", err)
 	}
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type UnRegisterCandidateParam struct {
@@ -313,102 +436,80 @@ type UnRegisterCandidateParam struct {
 	PeerPubkey string
 }
 
-func UnRegisterCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UnRegisterCandidate.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UnRegisterCandidate(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	unRegisterCandidateParam := new(UnRegisterCandidateParam)
-	err = json.Unmarshal(data, unRegisterCandidateParam)
+	err := env.Params.Load(unRegisterCandidateParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, unRegisterCandidateParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, unRegisterCandidateParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = unRegisterCandidate(ontSdk, user, unRegisterCandidateParam.PeerPubkey)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).UnRegisterCandidate(client.NewAccountSigner(user), unRegisterCandidateParam.PeerPubkey)
+	if err != nil {
+		return result, fmt.Errorf("UnRegisterCandidate error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type ApproveCandidateParam struct {
 	Path       []string
 	PeerPubkey []string
+	MultiSignParam
 }
 
-func ApproveCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ApproveCandidate.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ApproveCandidate(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	approveCandidateParam := new(ApproveCandidateParam)
-	err = json.Unmarshal(data, approveCandidateParam)
+	err := env.Params.Load(approveCandidateParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range approveCandidateParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, approveCandidateParam.Signers, approveCandidateParam.Path, nil, approveCandidateParam.M, approveCandidateParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
 	for _, peerPubkey := range approveCandidateParam.PeerPubkey {
-		ok := approveCandidateMultiSign(ontSdk, pubKeys, users, peerPubkey)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).ApproveCandidate(signer, peerPubkey)
+		if err != nil {
+			return result, fmt.Errorf("ApproveCandidate %s error:%s", peerPubkey, err)
+		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type RejectCandidateParam struct {
 	Path       []string
 	PeerPubkey string
+	MultiSignParam
 }
 
-func RejectCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RejectCandidate.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func RejectCandidate(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	rejectCandidateParam := new(RejectCandidateParam)
-	err = json.Unmarshal(data, rejectCandidateParam)
+	err := env.Params.Load(rejectCandidateParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range rejectCandidateParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, rejectCandidateParam.Signers, rejectCandidateParam.Path, nil, rejectCandidateParam.M, rejectCandidateParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
-	ok := rejectCandidateMultiSign(ontSdk, pubKeys, users, rejectCandidateParam.PeerPubkey)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).RejectCandidate(signer, rejectCandidateParam.PeerPubkey)
+	if err != nil {
+		return result, fmt.Errorf("RejectCandidate error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type ChangeMaxAuthorizationParam struct {
@@ -417,31 +518,29 @@ type ChangeMaxAuthorizationParam struct {
 	MaxAuthorizeList []uint32
 }
 
-func ChangeMaxAuthorization(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ChangeMaxAuthorization.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ChangeMaxAuthorization(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	changeMaxAuthorizationParam := new(ChangeMaxAuthorizationParam)
-	err = json.Unmarshal(data, changeMaxAuthorizationParam)
+	err := env.Params.Load(changeMaxAuthorizationParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, path := range changeMaxAuthorizationParam.PathList {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = changeMaxAuthorization(ontSdk, user, changeMaxAuthorizationParam.PeerPubkeyList[index], changeMaxAuthorizationParam.MaxAuthorizeList[index])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(env.Sdk, path)
+		if err != nil {
+			return result, err
+		}
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).ChangeMaxAuthorization(client.NewAccountSigner(user), changeMaxAuthorizationParam.PeerPubkeyList[index], changeMaxAuthorizationParam.MaxAuthorizeList[index])
+		if err != nil {
+			return result, fmt.Errorf("ChangeMaxAuthorization %s error:%s", changeMaxAuthorizationParam.PeerPubkeyList[index], err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type SetFeePercentageParam struct {
@@ -451,31 +550,29 @@ type SetFeePercentageParam struct {
 	StakeCostList  []uint32
 }
 
-func SetFeePercentage(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/SetPeerCost.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func SetFeePercentage(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	setFeePercentageParam := new(SetFeePercentageParam)
-	err = json.Unmarshal(data, setFeePercentageParam)
+	err := env.Params.Load(setFeePercentageParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, path := range setFeePercentageParam.PathList {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = setFeePercentage(ontSdk, user, setFeePercentageParam.PeerPubkeyList[index], setFeePercentageParam.PeerCostList[index], setFeePercentageParam.StakeCostList[index])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(env.Sdk, path)
+		if err != nil {
+			return result, err
+		}
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).SetFeePercentage(client.NewAccountSigner(user), setFeePercentageParam.PeerPubkeyList[index], setFeePercentageParam.PeerCostList[index], setFeePercentageParam.StakeCostList[index])
+		if err != nil {
+			return result, fmt.Errorf("SetFeePercentage %s error:%s", setFeePercentageParam.PeerPubkeyList[index], err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type AddInitPosParam struct {
@@ -484,29 +581,24 @@ type AddInitPosParam struct {
 	Pos        uint32
 }
 
-func AddInitPos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AddInitPos.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AddInitPos(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	addInitPosParam := new(AddInitPosParam)
-	err = json.Unmarshal(data, addInitPosParam)
+	err := env.Params.Load(addInitPosParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, addInitPosParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, addInitPosParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = addInitPos(ontSdk, user, addInitPosParam.PeerPubkey, addInitPosParam.Pos)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).AddInitPos(client.NewAccountSigner(user), addInitPosParam.PeerPubkey, addInitPosParam.Pos)
+	if err != nil {
+		return result, fmt.Errorf("AddInitPos error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type ReduceInitPosParam struct {
@@ -515,29 +607,24 @@ type ReduceInitPosParam struct {
 	Pos        uint32
 }
 
-func ReduceInitPos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ReduceInitPos.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ReduceInitPos(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	reduceInitPosParam := new(ReduceInitPosParam)
-	err = json.Unmarshal(data, reduceInitPosParam)
+	err := env.Params.Load(reduceInitPosParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, reduceInitPosParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, reduceInitPosParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = reduceInitPos(ontSdk, user, reduceInitPosParam.PeerPubkey, reduceInitPosParam.Pos)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).ReduceInitPos(client.NewAccountSigner(user), reduceInitPosParam.PeerPubkey, reduceInitPosParam.Pos)
+	if err != nil {
+		return result, fmt.Errorf("ReduceInitPos error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type AuthorizeForPeerParam struct {
@@ -546,52 +633,42 @@ type AuthorizeForPeerParam struct {
 	PosList        []uint32
 }
 
-func AuthorizeForPeer(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AuthorizeForPeer.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AuthorizeForPeer(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	authorizeForPeerParam := new(AuthorizeForPeerParam)
-	err = json.Unmarshal(data, authorizeForPeerParam)
+	err := env.Params.Load(authorizeForPeerParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, authorizeForPeerParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = authorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).AuthorizeForPeer(client.NewAccountSigner(user), authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
+	if err != nil {
+		return result, fmt.Errorf("AuthorizeForPeer error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
-func UnAuthorizeForPeer(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UnAuthorizeForPeer.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UnAuthorizeForPeer(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	authorizeForPeerParam := new(AuthorizeForPeerParam)
-	err = json.Unmarshal(data, authorizeForPeerParam)
+	err := env.Params.Load(authorizeForPeerParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, authorizeForPeerParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = unAuthorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).UnAuthorizeForPeer(client.NewAccountSigner(user), authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
+	if err != nil {
+		return result, fmt.Errorf("UnAuthorizeForPeer error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type WithdrawParam struct {
@@ -600,28 +677,23 @@ type WithdrawParam struct {
 	WithdrawList   []uint32
 }
 
-func Withdraw(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/Withdraw.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func Withdraw(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	withdrawParam := new(WithdrawParam)
-	err = json.Unmarshal(data, withdrawParam)
+	err := env.Params.Load(withdrawParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, withdrawParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, withdrawParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = withdraw(ontSdk, user, withdrawParam.PeerPubkeyList, withdrawParam.WithdrawList)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).Withdraw(client.NewAccountSigner(user), withdrawParam.PeerPubkeyList, withdrawParam.WithdrawList)
+	if err != nil {
+		return result, fmt.Errorf("Withdraw error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type QuitNodeParam struct {
@@ -629,138 +701,106 @@ type QuitNodeParam struct {
 	PeerPubkey []string
 }
 
-func QuitNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/QuitNode.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func QuitNode(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	quitNodeParam := new(QuitNodeParam)
-	err = json.Unmarshal(data, quitNodeParam)
+	err := env.Params.Load(quitNodeParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for i := 0; i < len(quitNodeParam.Path); i++ {
-		user, ok := common.GetAccountByPassword(ontSdk, quitNodeParam.Path[i])
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = quitNode(ontSdk, user, quitNodeParam.PeerPubkey[i])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(env.Sdk, quitNodeParam.Path[i])
+		if err != nil {
+			return result, err
 		}
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).QuitNode(client.NewAccountSigner(user), quitNodeParam.PeerPubkey[i])
+		if err != nil {
+			return result, fmt.Errorf("QuitNode %s error:%s", quitNodeParam.PeerPubkey[i], err)
+		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type BlackNodeParam struct {
 	Path           []string
 	PeerPubkeyList []string
+	MultiSignParam
 }
 
-func BlackNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/BlackNode.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func BlackNode(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	blackNodeParam := new(BlackNodeParam)
-	err = json.Unmarshal(data, blackNodeParam)
+	err := env.Params.Load(blackNodeParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range blackNodeParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, blackNodeParam.Signers, blackNodeParam.Path, nil, blackNodeParam.M, blackNodeParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
-	ok := blackNodeMultiSign(ontSdk, pubKeys, users, blackNodeParam.PeerPubkeyList)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).BlackNode(signer, blackNodeParam.PeerPubkeyList)
+	if err != nil {
+		return result, fmt.Errorf("BlackNode error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type WhiteNodeParam struct {
 	Path       []string
 	PeerPubkey string
+	MultiSignParam
 }
 
-func WhiteNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/WhiteNode.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func WhiteNode(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	whiteNodeParam := new(WhiteNodeParam)
-	err = json.Unmarshal(data, whiteNodeParam)
+	err := env.Params.Load(whiteNodeParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range whiteNodeParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, whiteNodeParam.Signers, whiteNodeParam.Path, nil, whiteNodeParam.M, whiteNodeParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
-	ok := whiteNodeMultiSign(ontSdk, pubKeys, users, whiteNodeParam.PeerPubkey)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).WhiteNode(signer, whiteNodeParam.PeerPubkey)
+	if err != nil {
+		return result, fmt.Errorf("WhiteNode error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type MultiAccount struct {
 	Path []string
+	MultiSignParam
 }
 
-func CommitDpos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/CommitDpos.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func CommitDpos(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	multiAccount := new(MultiAccount)
-	err = json.Unmarshal(data, multiAccount)
+	err := env.Params.Load(multiAccount)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range multiAccount.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, multiAccount.Signers, multiAccount.Path, nil, multiAccount.M, multiAccount.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
-	ok := commitDposMultiSign(ontSdk, pubKeys, users)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).CommitDpos(signer)
+	if err != nil {
+		return result, fmt.Errorf("CommitDpos error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type UpdateConfigParam struct {
@@ -773,30 +813,16 @@ type UpdateConfigParam struct {
 	HashMsgDelay         uint32
 	PeerHandshakeTimeout uint32
 	MaxBlockChangeView   uint32
+	MultiSignParam
+	ConfirmParam
 }
 
-func UpdateConfig(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateConfig.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateConfig(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	updateConfigParam := new(UpdateConfigParam)
-	err = json.Unmarshal(data, updateConfigParam)
+	err := env.Params.Load(updateConfigParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
-	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	time.Sleep(1 * time.Second)
-	for _, path := range updateConfigParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+		return result, err
 	}
 	config := &governance.Configuration{
 		N:                    updateConfigParam.N,
@@ -808,12 +834,38 @@ func UpdateConfig(ontSdk *sdk.OntologySdk) bool {
 		PeerHandshakeTimeout: updateConfigParam.PeerHandshakeTimeout,
 		MaxBlockChangeView:   updateConfigParam.MaxBlockChangeView,
 	}
-	ok := updateConfigMultiSign(ontSdk, pubKeys, users, config)
-	if !ok {
-		return false
+	governanceClient := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	liveConfig, err := governanceClient.GetVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetVbftConfig error:%s", err)
+	}
+	globalParam, err := governanceClient.GetGlobalParam()
+	if err != nil {
+		return result, fmt.Errorf("GetGlobalParam error:%s", err)
+	}
+	peerPoolMap, err := governanceClient.GetPeerPoolMap()
+	if err != nil {
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
+	}
+	err = rejectUpdate("UpdateConfig", checkConfig(config, countCandidates(peerPoolMap), globalParam))
+	if err != nil {
+		return result, err
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = confirmUpdate(env, result, "vbftConfig", diffFields(liveConfig, config), updateConfigParam.Confirmed)
+	if err != nil {
+		return result, err
+	}
+	time.Sleep(1 * time.Second)
+	signer, err := getMultiSigners(ctx, env, updateConfigParam.Signers, updateConfigParam.Path, nil, updateConfigParam.M, updateConfigParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
+	}
+	txHash, err := governanceClient.UpdateConfig(signer, config)
+	if err != nil {
+		return result, fmt.Errorf("UpdateConfig error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type UpdateGlobalParamParam struct {
@@ -826,30 +878,16 @@ type UpdateGlobalParamParam struct {
 	B            uint32
 	Yita         uint32
 	Penalty      uint32
+	MultiSignParam
+	ConfirmParam
 }
 
-func UpdateGlobalParam(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateGlobalParam.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateGlobalParam(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	updateGlobalParamParam := new(UpdateGlobalParamParam)
-	err = json.Unmarshal(data, updateGlobalParamParam)
+	err := env.Params.Load(updateGlobalParamParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
-	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	time.Sleep(1 * time.Second)
-	for _, path := range updateGlobalParamParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+		return result, err
 	}
 	globalParam := &governance.GlobalParam{
 		CandidateFee: updateGlobalParamParam.CandidateFee,
@@ -861,315 +899,308 @@ func UpdateGlobalParam(ontSdk *sdk.OntologySdk) bool {
 		Yita:         updateGlobalParamParam.Yita,
 		Penalty:      updateGlobalParamParam.Penalty,
 	}
-	ok := updateGlobalParamMultiSign(ontSdk, pubKeys, users, globalParam)
-	if !ok {
-		return false
+	governanceClient := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	liveGlobalParam, err := governanceClient.GetGlobalParam()
+	if err != nil {
+		return result, fmt.Errorf("GetGlobalParam error:%s", err)
+	}
+	config, err := governanceClient.GetVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetVbftConfig error:%s", err)
+	}
+	err = rejectUpdate("UpdateGlobalParam", checkGlobalParam(globalParam, config.K))
+	if err != nil {
+		return result, err
+	}
+	nextConfig, err := governanceClient.GetNextVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetNextVbftConfig error:%s", err)
+	}
+	if globalParam.CandidateNum < 4*nextConfig.K {
+		result.AddWarning(fmt.Sprintf("CandidateNum %d is less than 4*K %d of the config updated for the next view",
+			globalParam.CandidateNum, 4*nextConfig.K))
+	}
+	peerPoolMap, err := governanceClient.GetPeerPoolMap()
+	if err != nil {
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
+	}
+	candidateNum := countCandidates(peerPoolMap)
+	if int(globalParam.CandidateNum) <= candidateNum {
+		result.AddWarning(fmt.Sprintf("CandidateNum %d is not more than the %d candidate and consensus nodes, registerCandidate fails until nodes quit",
+			globalParam.CandidateNum, candidateNum))
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = confirmUpdate(env, result, "globalParam", diffFields(liveGlobalParam, globalParam), updateGlobalParamParam.Confirmed)
+	if err != nil {
+		return result, err
+	}
+	time.Sleep(1 * time.Second)
+	signer, err := getMultiSigners(ctx, env, updateGlobalParamParam.Signers, updateGlobalParamParam.Path, nil, updateGlobalParamParam.M, updateGlobalParamParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
+	}
+	txHash, err := governanceClient.UpdateGlobalParam(signer, globalParam)
+	if err != nil {
+		return result, fmt.Errorf("UpdateGlobalParam error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type UpdateGlobalParamParam2 struct {
 	Path                 []string
 	MinAuthorizePos      uint32
 	CandidateFeeSplitNum uint32
+	MultiSignParam
+	ConfirmParam
 }
 
-func UpdateGlobalParam2(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateGlobalParam2.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateGlobalParam2(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	updateGlobalParamParam2 := new(UpdateGlobalParamParam2)
-	err = json.Unmarshal(data, updateGlobalParamParam2)
+	err := env.Params.Load(updateGlobalParamParam2)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	time.Sleep(1 * time.Second)
-	for _, path := range updateGlobalParamParam2.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	governanceClient := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	liveGlobalParam2, err := governanceClient.GetGlobalParam2()
+	if err != nil {
+		return result, fmt.Errorf("GetGlobalParam2 error:%s", err)
 	}
 	globalParam2 := &governance.GlobalParam2{
 		MinAuthorizePos:      updateGlobalParamParam2.MinAuthorizePos,
 		CandidateFeeSplitNum: updateGlobalParamParam2.CandidateFeeSplitNum,
 	}
-	ok := updateGlobalParam2MultiSign(ontSdk, pubKeys, users, globalParam2)
-	if !ok {
-		return false
+	config, err := governanceClient.GetVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetVbftConfig error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	height, err := env.Chain.GetCurrentBlockHeight()
+	if err != nil {
+		return result, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	err = rejectUpdate("UpdateGlobalParam2", checkGlobalParam2(globalParam2, config.K, height))
+	if err != nil {
+		return result, err
+	}
+	err = confirmUpdate(env, result, "globalParam2", diffFields(liveGlobalParam2, globalParam2), updateGlobalParamParam2.Confirmed)
+	if err != nil {
+		return result, err
+	}
+	time.Sleep(1 * time.Second)
+	signer, err := getMultiSigners(ctx, env, updateGlobalParamParam2.Signers, updateGlobalParamParam2.Path, nil, updateGlobalParamParam2.M, updateGlobalParamParam2.ExpectedAddress, true)
+	if err != nil {
+		return result, err
+	}
+	txHash, err := governanceClient.UpdateGlobalParam2(signer, globalParam2)
+	if err != nil {
+		return result, fmt.Errorf("UpdateGlobalParam2 error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type UpdateSplitCurveParam struct {
 	Path []string
 	Yi   []uint32
+	MultiSignParam
+	ConfirmParam
 }
 
-func UpdateSplitCurve(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateSplitCurve.json")
+func UpdateSplitCurve(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	updateSplitCurveParam := new(UpdateSplitCurveParam)
+	err := env.Params.Load(updateSplitCurveParam)
 	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
+		return result, err
 	}
-	updateSplitCurveParam := new(UpdateSplitCurveParam)
-	err = json.Unmarshal(data, updateSplitCurveParam)
+	err = rejectUpdate("UpdateSplitCurve", checkSplitCurve(updateSplitCurveParam.Yi))
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
+	}
+	if warning := splitCurveShape(updateSplitCurveParam.Yi); warning != "" {
+		result.AddWarning(warning)
+	}
+	governanceClient := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	liveSplitCurve, err := governanceClient.GetSplitCurve()
+	if err != nil {
+		return result, fmt.Errorf("GetSplitCurve error:%s", err)
+	}
+	err = confirmUpdate(env, result, "splitCurve", diffSplitCurve(liveSplitCurve.Yi, updateSplitCurveParam.Yi), updateSplitCurveParam.Confirmed)
+	if err != nil {
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range updateSplitCurveParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, updateSplitCurveParam.Signers, updateSplitCurveParam.Path, nil, updateSplitCurveParam.M, updateSplitCurveParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
 	splitCurve := &governance.SplitCurve{
 		Yi: updateSplitCurveParam.Yi,
 	}
-	ok := updateSplitCurveMultiSign(ontSdk, pubKeys, users, splitCurve)
-	if !ok {
-		return false
+	txHash, err := governanceClient.UpdateSplitCurve(signer, splitCurve)
+	if err != nil {
+		return result, fmt.Errorf("UpdateSplitCurve error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type SetPromisePosParam struct {
 	Path       []string
 	PeerPubkey []string
 	PromisePos []uint64
+	MultiSignParam
 }
 
-func SetPromisePos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/SetPromisePos.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func SetPromisePos(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	setPromisePosParam := new(SetPromisePosParam)
-	err = json.Unmarshal(data, setPromisePosParam)
+	err := env.Params.Load(setPromisePosParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range setPromisePosParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, setPromisePosParam.Signers, setPromisePosParam.Path, nil, setPromisePosParam.M, setPromisePosParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
 	for index, peerPubkey := range setPromisePosParam.PeerPubkey {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		promisePos := &governance.PromisePos{
 			PeerPubkey: peerPubkey,
 			PromisePos: setPromisePosParam.PromisePos[index],
 		}
-		ok := setPromisePosMultiSign(ontSdk, pubKeys, users, promisePos)
-		if !ok {
-			return false
+		txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).SetPromisePos(signer, promisePos)
+		if err != nil {
+			return result, fmt.Errorf("SetPromisePos %s error:%s", peerPubkey, err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type TransferPenaltyParam struct {
 	Path       []string
 	PeerPubkey string
 	Address    string
+	MultiSignParam
 }
 
-func TransferPenalty(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferPenalty.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferPenalty(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferPenaltyParam := new(TransferPenaltyParam)
-	err = json.Unmarshal(data, transferPenaltyParam)
+	err := env.Params.Load(transferPenaltyParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferPenaltyParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferPenaltyParam.Signers, transferPenaltyParam.Path, nil, transferPenaltyParam.M, transferPenaltyParam.ExpectedAddress, true)
+	if err != nil {
+		return result, err
 	}
 	address, err := ocommon.AddressFromBase58(transferPenaltyParam.Address)
 	if err != nil {
-		return false
+		return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 	}
-	ok := transferPenaltyMultiSign(ontSdk, pubKeys, users, transferPenaltyParam.PeerPubkey, address)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).TransferPenalty(signer, transferPenaltyParam.PeerPubkey, address)
+	if err != nil {
+		return result, fmt.Errorf("TransferPenalty error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
-func GetVbftConfig(ontSdk *sdk.OntologySdk) bool {
-	config, err := getVbftConfig(ontSdk)
+func GetVbftConfig(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	config, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetVbftConfig()
 	if err != nil {
-		log4.Error("getVbftConfig failed ", err)
-		return false
+		return result, fmt.Errorf("GetVbftConfig error:%s", err)
 	}
-	fmt.Println("config.N is:", config.N)
-	fmt.Println("config.C is:", config.C)
-	fmt.Println("config.K is:", config.K)
-	fmt.Println("config.L is:", config.L)
-	fmt.Println("config.BlockMsgDelay is:", config.BlockMsgDelay)
-	fmt.Println("config.HashMsgDelay is:", config.HashMsgDelay)
-	fmt.Println("config.PeerHandshakeTimeout is:", config.PeerHandshakeTimeout)
-	fmt.Println("config.MaxBlockChangeView is:", config.MaxBlockChangeView)
-	return true
+	result.AddOutput("config", config)
+	return result, nil
 }
 
-func GetPreConfig(ontSdk *sdk.OntologySdk) bool {
-	config, err := getPreConfig(ontSdk)
+func GetPreConfig(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	config, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetPreConfig()
 	if err != nil {
-		log4.Error("getVbftConfig failed ", err)
-		return false
+		return result, fmt.Errorf("GetPreConfig error:%s", err)
 	}
-	fmt.Println("config.N is:", config.N)
-	fmt.Println("config.C is:", config.C)
-	fmt.Println("config.K is:", config.K)
-	fmt.Println("config.L is:", config.L)
-	fmt.Println("config.BlockMsgDelay is:", config.BlockMsgDelay)
-	fmt.Println("config.HashMsgDelay is:", config.HashMsgDelay)
-	fmt.Println("config.PeerHandshakeTimeout is:", config.PeerHandshakeTimeout)
-	fmt.Println("config.MaxBlockChangeView is:", config.MaxBlockChangeView)
-	return true
+	result.AddOutput("config", config)
+	return result, nil
 }
 
-func GetGlobalParam(ontSdk *sdk.OntologySdk) bool {
-	globalParam, err := getGlobalParam(ontSdk)
+func GetGlobalParam(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	globalParam, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetGlobalParam()
 	if err != nil {
-		log4.Error("getGlobalParam failed ", err)
-		return false
+		return result, fmt.Errorf("GetGlobalParam error:%s", err)
 	}
-	fmt.Println("globalParam.CandidateFee is:", globalParam.CandidateFee)
-	fmt.Println("globalParam.MinInitStake is:", globalParam.MinInitStake)
-	fmt.Println("globalParam.CandidateNum is:", globalParam.CandidateNum)
-	fmt.Println("globalParam.PosLimit is:", globalParam.PosLimit)
-	fmt.Println("globalParam.A is:", globalParam.A)
-	fmt.Println("globalParam.B is:", globalParam.B)
-	fmt.Println("globalParam.Yita is:", globalParam.Yita)
-	fmt.Println("globalParam.Penalty is:", globalParam.Penalty)
-	return true
+	result.AddOutput("globalParam", globalParam)
+	return result, nil
 }
 
-func GetGlobalParam2(ontSdk *sdk.OntologySdk) bool {
-	globalParam2, err := getGlobalParam2(ontSdk)
+func GetGlobalParam2(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	globalParam2, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetGlobalParam2()
 	if err != nil {
-		log4.Error("getGlobalParam failed ", err)
-		return false
+		return result, fmt.Errorf("GetGlobalParam2 error:%s", err)
 	}
-	fmt.Println("globalParam2.MinAuthorizePos is:", globalParam2.MinAuthorizePos)
-	fmt.Println("globalParam2.CandidateFeeSplitNum is:", globalParam2.CandidateFeeSplitNum)
-	return true
+	result.AddOutput("globalParam2", globalParam2)
+	return result, nil
 }
 
-func GetSplitCurve(ontSdk *sdk.OntologySdk) bool {
-	splitCurve, err := getSplitCurve(ontSdk)
+func GetSplitCurve(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	splitCurve, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetSplitCurve()
 	if err != nil {
-		log4.Error("getSplitCurve failed ", err)
-		return false
+		return result, fmt.Errorf("GetSplitCurve error:%s", err)
 	}
-	fmt.Println("splitCurve.Yi is", splitCurve.Yi)
-	return true
+	result.AddOutput("splitCurve", splitCurve)
+	return result, nil
 }
 
-func GetGovernanceView(ontSdk *sdk.OntologySdk) bool {
-	governanceView, err := getGovernanceView(ontSdk)
+func GetGovernanceView(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	governanceView, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetGovernanceView()
 	if err != nil {
-		log4.Error("getGovernanceView failed ", err)
-		return false
+		return result, fmt.Errorf("GetGovernanceView error:%s", err)
 	}
-	fmt.Println("governanceView.View is:", governanceView.View)
-	fmt.Println("governanceView.TxHash is:", governanceView.TxHash)
-	fmt.Println("governanceView.Height is:", governanceView.Height)
-	return true
+	result.AddOutput("governanceView", governanceView)
+	return result, nil
 }
 
 type GetPeerPoolItemParam struct {
 	PeerPubkey string
 }
 
-func GetPeerPoolItem(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPeerPoolItem.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPeerPoolItem(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getPeerPoolItemParam := new(GetPeerPoolItemParam)
-	err = json.Unmarshal(data, getPeerPoolItemParam)
+	err := env.Params.Load(getPeerPoolItemParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 
-	peerPoolMap, err := getPeerPoolMap(ontSdk)
+	peerPoolItem, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetPeerPoolItem(getPeerPoolItemParam.PeerPubkey)
 	if err != nil {
-		log4.Error("getPeerPoolMap failed ", err)
-		return false
-	}
-
-	peerPoolItem, ok := peerPoolMap.PeerPoolMap[getPeerPoolItemParam.PeerPubkey]
-	if !ok {
-		fmt.Println("Can't find peerPubkey in peerPoolMap")
+		return result, fmt.Errorf("GetPeerPoolItem error:%s", err)
 	}
-	fmt.Println("peerPoolItem.Index is:", peerPoolItem.Index)
-	fmt.Println("peerPoolItem.PeerPubkey is:", peerPoolItem.PeerPubkey)
-	fmt.Println("peerPoolItem.Address is:", peerPoolItem.Address.ToBase58())
-	fmt.Println("peerPoolItem.Status is:", peerPoolItem.Status)
-	fmt.Println("peerPoolItem.InitPos is:", peerPoolItem.InitPos)
-	fmt.Println("peerPoolItem.TotalPos is:", peerPoolItem.TotalPos)
-	return true
+	result.AddOutput("peerPoolItem", peerPoolItem)
+	return result, nil
 }
 
-func GetPeerPoolMap(ontSdk *sdk.OntologySdk) bool {
-	peerPoolMap, err := getPeerPoolMap(ontSdk)
+func GetPeerPoolMap(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	peerPoolMap, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetPeerPoolMap()
 	if err != nil {
-		log4.Error("getPeerPoolMap failed ", err)
-		return false
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
 	}
 
-	for _, v := range peerPoolMap.PeerPoolMap {
-		fmt.Println("###########################################")
-		fmt.Println("peerPoolItem.Index is:", v.Index)
-		fmt.Println("peerPoolItem.PeerPubkey is:", v.PeerPubkey)
-		fmt.Println("peerPoolItem.Address is:", v.Address.ToBase58())
-		fmt.Println("peerPoolItem.Status is:", v.Status)
-		fmt.Println("peerPoolItem.InitPos is:", v.InitPos)
-		fmt.Println("peerPoolItem.TotalPos is:", v.TotalPos)
-	}
-	return true
+	result.AddOutput("peerPoolMap", peerPoolMap)
+	return result, nil
 }
 
 type GetAuthorizeInfoParam struct {
@@ -1177,131 +1208,92 @@ type GetAuthorizeInfoParam struct {
 	PeerPubkey string
 }
 
-func GetAuthorizeInfo(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAuthorizeInfo.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAuthorizeInfo(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getAuthorizeInfoParam := new(GetAuthorizeInfoParam)
-	err = json.Unmarshal(data, getAuthorizeInfoParam)
+	err := env.Params.Load(getAuthorizeInfoParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 
 	address, err := ocommon.AddressFromBase58(getAuthorizeInfoParam.Address)
 	if err != nil {
-		log4.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 	}
-	authorizeInfo, err := getAuthorizeInfo(ontSdk, getAuthorizeInfoParam.PeerPubkey, address)
+	authorizeInfo, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetAuthorizeInfo(getAuthorizeInfoParam.PeerPubkey, address)
 	if err != nil {
-		log4.Error("getAuthorizeInfo failed ", err)
-		return false
+		return result, fmt.Errorf("GetAuthorizeInfo error:%s", err)
 	}
 
-	fmt.Println("authorizeInfo.PeerPubkey is:", authorizeInfo.PeerPubkey)
-	fmt.Println("authorizeInfo.Address is:", authorizeInfo.Address.ToBase58())
-	fmt.Println("authorizeInfo.ConsensusPos is:", authorizeInfo.ConsensusPos)
-	fmt.Println("authorizeInfo.CandidatePos is:", authorizeInfo.CandidatePos)
-	fmt.Println("authorizeInfo.NewPos is:", authorizeInfo.NewPos)
-	fmt.Println("authorizeInfo.WithdrawConsensusPos is:", authorizeInfo.WithdrawConsensusPos)
-	fmt.Println("authorizeInfo.WithdrawCandidatePos is:", authorizeInfo.WithdrawCandidatePos)
-	fmt.Println("authorizeInfo.WithdrawUnfreezePos is:", authorizeInfo.WithdrawUnfreezePos)
-	return true
+	result.AddOutput("authorizeInfo", authorizeInfo)
+	return result, nil
 }
 
 type GetTotalStakeParam struct {
 	Address string
 }
 
-func GetTotalStake(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetTotalStake.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetTotalStake(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getTotalStakeParam := new(GetTotalStakeParam)
-	err = json.Unmarshal(data, getTotalStakeParam)
+	err := env.Params.Load(getTotalStakeParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	address, err := ocommon.AddressFromBase58(getTotalStakeParam.Address)
 	if err != nil {
-		log4.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 	}
 
-	totalStake, err := getTotalStake(ontSdk, address)
+	totalStake, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetTotalStake(address)
 	if err != nil {
-		log4.Error("getTotalStake failed ", err)
-		return false
+		return result, fmt.Errorf("GetTotalStake error:%s", err)
 	}
 
-	fmt.Println("totalStake.Address is:", totalStake.Address.ToBase58())
-	fmt.Println("totalStake.Stake is:", totalStake.Stake)
-	fmt.Println("totalStake.TimeOffset is:", totalStake.TimeOffset)
-	return true
+	result.AddOutput("totalStake", totalStake)
+	return result, nil
 }
 
 type GetPenaltyStakeParam struct {
 	PeerPubkey string
 }
 
-func GetPenaltyStake(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPenaltyStake.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPenaltyStake(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getPenaltyStakeParam := new(GetPenaltyStakeParam)
-	err = json.Unmarshal(data, getPenaltyStakeParam)
+	err := env.Params.Load(getPenaltyStakeParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 
-	penaltyStake, err := getPenaltyStake(ontSdk, getPenaltyStakeParam.PeerPubkey)
+	penaltyStake, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetPenaltyStake(getPenaltyStakeParam.PeerPubkey)
 	if err != nil {
-		log4.Error("getPenaltyStake failed ", err)
-		return false
+		return result, fmt.Errorf("GetPenaltyStake error:%s", err)
 	}
 
-	fmt.Println("penaltyStake.PeerPubkey is:", penaltyStake.PeerPubkey)
-	fmt.Println("penaltyStake.InitPos is:", penaltyStake.InitPos)
-	fmt.Println("penaltyStake.AuthorizePos is:", penaltyStake.AuthorizePos)
-	fmt.Println("penaltyStake.TimeOffset is:", penaltyStake.TimeOffset)
-	fmt.Println("penaltyStake.Amount is:", penaltyStake.Amount)
-	return true
+	result.AddOutput("penaltyStake", penaltyStake)
+	return result, nil
 }
 
 type InBlackListParam struct {
 	PeerPubkey string
 }
 
-func InBlackList(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/InBlackList.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func InBlackList(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	inBlackListParam := new(InBlackListParam)
-	err = json.Unmarshal(data, inBlackListParam)
+	err := env.Params.Load(inBlackListParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 
-	inBlackList, err := inBlackList(ontSdk, inBlackListParam.PeerPubkey)
+	inBlackList, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).InBlackList(inBlackListParam.PeerPubkey)
 	if err != nil {
-		log4.Error("getPenaltyStake failed ", err)
-		return false
+		return result, fmt.Errorf("InBlackList error:%s", err)
 	}
 
-	fmt.Println("result is:", inBlackList)
-	return true
+	result.AddOutput("inBlackList", inBlackList)
+	return result, nil
 }
 
 type WithdrawOngParam struct {
@@ -1309,28 +1301,23 @@ type WithdrawOngParam struct {
 	PeerPubkey string
 }
 
-func WithdrawOng(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/WithdrawOng.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func WithdrawOng(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	withdrawOngParam := new(WithdrawOngParam)
-	err = json.Unmarshal(data, withdrawOngParam)
+	err := env.Params.Load(withdrawOngParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, withdrawOngParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, withdrawOngParam.Path)
+	if err != nil {
+		return result, err
 	}
-	ok = withdrawOng(ontSdk, user)
-	if !ok {
-		return false
+	txHash, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).WithdrawOng(client.NewAccountSigner(user))
+	if err != nil {
+		return result, fmt.Errorf("WithdrawOng error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type VrfParam struct {
@@ -1342,363 +1329,271 @@ type vrfData struct {
 	PrevVrf  []byte `json:"prev_vrf"`
 }
 
-func Vrf(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/Vrf.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func Vrf(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	vrfParam := new(VrfParam)
-	err = json.Unmarshal(data, vrfParam)
+	err := env.Params.Load(vrfParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, vrfParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(env.Sdk, vrfParam.Path)
+	if err != nil {
+		return result, err
 	}
 
-	data, err = json.Marshal(&vrfData{
+	data, err := json.Marshal(&vrfData{
 		BlockNum: 0,
 		PrevVrf:  keypair.SerializePublicKey(user.PublicKey),
 	})
 	if err != nil {
-		log4.Error("json.Unmarshal vrf payload failed ", err)
-		return false
+		return result, fmt.Errorf("json.Marshal vrf payload error:%s", err)
 	}
 
 	value, proof, err := vrf.Vrf(user.PrivateKey, data)
 	if err != nil {
-		log4.Error("vrf computation failed ", err)
-		return false
+		return result, fmt.Errorf("vrf computation error:%s", err)
 	}
 
 	if ok, err := vrf.Verify(user.PublicKey, data, value, proof); err != nil || !ok {
-		log4.Error("vrf verify failed: ", err)
-		return false
+		return result, fmt.Errorf("vrf verify failed:%v", err)
 	}
 
-	log4.Info("vrf value: %s", hex.EncodeToString(value))
-	log4.Info("vrf proof: %s", hex.EncodeToString(proof))
+	result.AddOutput("value", hex.EncodeToString(value))
+	result.AddOutput("proof", hex.EncodeToString(proof))
+	env.Logger.Info("vrf value: %s", hex.EncodeToString(value))
+	env.Logger.Info("vrf proof: %s", hex.EncodeToString(proof))
 
-	return true
+	return result, nil
 }
 
 type TransferMultiSignParam struct {
 	Path1  []string
 	Path2  []string
 	Amount []uint64
+	MultiSignParam
 }
 
-func TransferOntMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignParam := new(TransferMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignParam)
+	err := env.Params.Load(transferMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignParam.Signers, transferMultiSignParam.Path1, nil, transferMultiSignParam.M, transferMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = transferOntMultiSign(ontSdk, pubKeys, users, user2.Address, transferMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		to, err := common.GetAddressByWallet(env.Sdk, path2)
+		if err != nil {
+			return result, err
+		}
+		txHash, err := client.NewOntClient(env.Chain, env.ClientOptions()).Transfer(signer, to, transferMultiSignParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferOnt to %s error:%s", to.ToBase58(), err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
-func TransferOngMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOngMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignParam := new(TransferMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignParam)
+	err := env.Params.Load(transferMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignParam.Signers, transferMultiSignParam.Path1, nil, transferMultiSignParam.M, transferMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = transferOngMultiSign(ontSdk, pubKeys, users, user2.Address, transferMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		to, err := common.GetAddressByWallet(env.Sdk, path2)
+		if err != nil {
+			return result, err
 		}
+		txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).Transfer(signer, to, transferMultiSignParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferOng to %s error:%s", to.ToBase58(), err)
+		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type TransferFromMultiSignParam struct {
 	Path1  []string
 	Path2  []string
 	Amount []uint64
+	MultiSignParam
 }
 
-func TransferFromOngMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferFromMultiSignParam := new(TransferFromMultiSignParam)
-	err = json.Unmarshal(data, transferFromMultiSignParam)
+	err := env.Params.Load(transferFromMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferFromMultiSignParam.Signers, transferFromMultiSignParam.Path1, nil, transferFromMultiSignParam.M, transferFromMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferFromMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		if err := ctx.Err(); err != nil {
+			return result, err
 		}
-		ok = transferFromOngMultiSign(ontSdk, pubKeys, users, user2.Address, transferFromMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		to, err := common.GetAddressByWallet(env.Sdk, path2)
+		if err != nil {
+			return result, err
+		}
+		txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).TransferFrom(signer, utils.OntContractAddress, to, transferFromMultiSignParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferFromOng to %s error:%s", to.ToBase58(), err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type GetAddressMultiSignParam struct {
 	PubKeys []string
+	MultiSignParam
 }
 
-func GetAddressMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAddressMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAddressMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getAddressMultiSignParam := new(GetAddressMultiSignParam)
-	err = json.Unmarshal(data, getAddressMultiSignParam)
+	err := env.Params.Load(getAddressMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var pubKeys []keypair.PublicKey
-	time.Sleep(1 * time.Second)
-	for _, v := range getAddressMultiSignParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeys = append(pubKeys, k)
-	}
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	members, err := getMultiSignMembers(env, getAddressMultiSignParam.Signers, nil, getAddressMultiSignParam.PubKeys,
+		getAddressMultiSignParam.M, getAddressMultiSignParam.ExpectedAddress)
 	if err != nil {
-		log4.Error("types.AddressFromMultiPubKeys error", err)
+		return result, err
 	}
-	fmt.Println("address is:", from.ToBase58())
-	return true
+	result.AddOutput("m", members.m)
+	result.AddOutput("address", members.address.ToBase58())
+	return result, nil
 }
 
 type TransferMultiSignToMultiSignParam struct {
 	Path1   []string
 	PubKeys []string
 	Amount  uint64
+	MultiSignParam
+	//MTo and ExpectedAddressTo are M and expected address of the multi-sign address of PubKeys
+	MTo               uint16
+	ExpectedAddressTo string
 }
 
-func TransferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSignToMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSignToMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignToMultiSignParam := new(TransferMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignToMultiSignParam)
+	err := env.Params.Load(transferMultiSignToMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignToMultiSignParam.Signers, transferMultiSignToMultiSignParam.Path1, nil, transferMultiSignToMultiSignParam.M, transferMultiSignToMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
-	for _, v := range transferMultiSignToMultiSignParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeysTo = append(pubKeysTo, k)
+	pubKeysTo, err := parsePubKeys(transferMultiSignToMultiSignParam.PubKeys)
+	if err != nil {
+		return result, err
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	_, to, err := common.MultiSignAddress(pubKeysTo, transferMultiSignToMultiSignParam.MTo, transferMultiSignToMultiSignParam.ExpectedAddressTo)
 	if err != nil {
-		log4.Error("types.AddressFromMultiPubKeys error", err)
+		return result, err
 	}
-	ok := transferOntMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	txHash, err := client.NewOntClient(env.Chain, env.ClientOptions()).Transfer(signer, to, transferMultiSignToMultiSignParam.Amount)
+	if err != nil {
+		return result, fmt.Errorf("TransferOnt error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
-func TransferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSignToMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOngMultiSignToMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignToMultiSignParam := new(TransferMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignToMultiSignParam)
+	err := env.Params.Load(transferMultiSignToMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignToMultiSignParam.Signers, transferMultiSignToMultiSignParam.Path1, nil, transferMultiSignToMultiSignParam.M, transferMultiSignToMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
-	for _, v := range transferMultiSignToMultiSignParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeysTo = append(pubKeysTo, k)
+	pubKeysTo, err := parsePubKeys(transferMultiSignToMultiSignParam.PubKeys)
+	if err != nil {
+		return result, err
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	_, to, err := common.MultiSignAddress(pubKeysTo, transferMultiSignToMultiSignParam.MTo, transferMultiSignToMultiSignParam.ExpectedAddressTo)
 	if err != nil {
-		log4.Error("types.AddressFromMultiPubKeys error", err)
+		return result, err
 	}
-	ok := transferOngMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).Transfer(signer, to, transferMultiSignToMultiSignParam.Amount)
+	if err != nil {
+		return result, fmt.Errorf("TransferOng error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type TransferFromMultiSignToMultiSignParam struct {
 	Path1   []string
 	PubKeys []string
 	Amount  uint64
+	MultiSignParam
+	//MTo and ExpectedAddressTo are M and expected address of the multi-sign address of PubKeys
+	MTo               uint16
+	ExpectedAddressTo string
 }
 
-func TransferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSignToMultiSign.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSignToMultiSign(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferFromMultiSignToMultiSignParam := new(TransferFromMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferFromMultiSignToMultiSignParam)
+	err := env.Params.Load(transferFromMultiSignToMultiSignParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
-	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferFromMultiSignToMultiSignParam.Signers, transferFromMultiSignToMultiSignParam.Path1, nil, transferFromMultiSignToMultiSignParam.M, transferFromMultiSignToMultiSignParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
-	for _, v := range transferFromMultiSignToMultiSignParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeysTo = append(pubKeysTo, k)
+	pubKeysTo, err := parsePubKeys(transferFromMultiSignToMultiSignParam.PubKeys)
+	if err != nil {
+		return result, err
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	_, to, err := common.MultiSignAddress(pubKeysTo, transferFromMultiSignToMultiSignParam.MTo, transferFromMultiSignToMultiSignParam.ExpectedAddressTo)
 	if err != nil {
-		log4.Error("types.AddressFromMultiPubKeys error", err)
+		return result, err
 	}
-	ok := transferFromOngMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferFromMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).TransferFrom(signer, utils.OntContractAddress, to, transferFromMultiSignToMultiSignParam.Amount)
+	if err != nil {
+		return result, fmt.Errorf("TransferFromOng error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type TransferMultiSignAddressParam struct {
@@ -1706,163 +1601,117 @@ type TransferMultiSignAddressParam struct {
 	PubKeys []string
 	Address []string
 	Amount  []uint64
+	MultiSignParam
 }
 
-func TransferOntMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSignAddress.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSignAddress(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignAddressParam := new(TransferMultiSignAddressParam)
-	err = json.Unmarshal(data, transferMultiSignAddressParam)
+	err := env.Params.Load(transferMultiSignAddressParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-	}
-	for _, v := range transferMultiSignAddressParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeys = append(pubKeys, k)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignAddressParam.Signers, transferMultiSignAddressParam.Path1, transferMultiSignAddressParam.PubKeys, transferMultiSignAddressParam.M, transferMultiSignAddressParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	for index, address := range transferMultiSignAddressParam.Address {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log4.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 		}
-		ok := transferOntMultiSign(ontSdk, pubKeys, users, addr, transferMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := client.NewOntClient(env.Chain, env.ClientOptions()).Transfer(signer, addr, transferMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferOnt to %s error:%s", address, err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
-func TransferOngMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSignAddress.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOngMultiSignAddress(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferMultiSignAddressParam := new(TransferMultiSignAddressParam)
-	err = json.Unmarshal(data, transferMultiSignAddressParam)
+	err := env.Params.Load(transferMultiSignAddressParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-	}
-	for _, v := range transferMultiSignAddressParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log4.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log4.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeys = append(pubKeys, k)
+	signer, err := getMultiSigners(ctx, env, transferMultiSignAddressParam.Signers, transferMultiSignAddressParam.Path1, transferMultiSignAddressParam.PubKeys, transferMultiSignAddressParam.M, transferMultiSignAddressParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	for index, address := range transferMultiSignAddressParam.Address {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log4.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 		}
-		ok := transferOngMultiSign(ontSdk, pubKeys, users, addr, transferMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).Transfer(signer, addr, transferMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferOng to %s error:%s", address, err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
 
 type TransferFromMultiSignAddressParam struct {
 	Path1   []string
 	Address []string
 	Amount  []uint64
+	MultiSignParam
 }
 
-func TransferFromOngMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSignAddress.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSignAddress(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	transferFromMultiSignAddressParam := new(TransferFromMultiSignAddressParam)
-	err = json.Unmarshal(data, transferFromMultiSignAddressParam)
+	err := env.Params.Load(transferFromMultiSignAddressParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signer, err := getMultiSigners(ctx, env, transferFromMultiSignAddressParam.Signers, transferFromMultiSignAddressParam.Path1, nil, transferFromMultiSignAddressParam.M, transferFromMultiSignAddressParam.ExpectedAddress, false)
+	if err != nil {
+		return result, err
 	}
 	time.Sleep(1 * time.Second)
 	for index, address := range transferFromMultiSignAddressParam.Address {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log4.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 		}
-		ok := transferFromOngMultiSign(ontSdk, pubKeys, users, addr, transferFromMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).TransferFrom(signer, utils.OntContractAddress, addr, transferFromMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return result, fmt.Errorf("TransferFromOng to %s error:%s", address, err)
 		}
+		result.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return result, nil
 }
-func GetVbftInfo(ontSdk *sdk.OntologySdk) bool {
-	blkNum, err := ontSdk.GetCurrentBlockHeight()
+
+func GetVbftInfo(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	blkNum, err := env.Chain.GetCurrentBlockHeight()
 	if err != nil {
-		log4.Error("TestGetVbftInfo GetBlockCount error:%s", err)
-		return false
+		return result, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
 	}
-	blk, err := ontSdk.GetBlockByHeight(blkNum - 1)
+	blk, err := env.Chain.GetBlockByHeight(blkNum - 1)
 	if err != nil {
-		log4.Error("TestGetVbftInfo GetBlockByHeight error:%s", err)
-		return false
+		return result, fmt.Errorf("GetBlockByHeight error:%s", err)
 	}
 	block, err := common.InitVbftBlock(blk)
 	if err != nil {
-		log4.Error("TestGetVbftInfo initVbftBlock error:%s", err)
-		return false
+		return result, fmt.Errorf("initVbftBlock error:%s", err)
 	}
 
 	var cfg vconfig.ChainConfig
@@ -1871,29 +1720,22 @@ func GetVbftInfo(ontSdk *sdk.OntologySdk) bool {
 	} else {
 		var cfgBlock *types.Block
 		if block.Info.LastConfigBlockNum != math.MaxUint32 {
-			cfgBlock, err = ontSdk.GetBlockByHeight(block.Info.LastConfigBlockNum)
+			cfgBlock, err = env.Chain.GetBlockByHeight(block.Info.LastConfigBlockNum)
 			if err != nil {
-				log4.Error("TestGetVbftInfo chainconfig GetBlockByHeight error:%s", err)
-				return false
+				return result, fmt.Errorf("chainconfig GetBlockByHeight error:%s", err)
 			}
 		}
 		blk, err := common.InitVbftBlock(cfgBlock)
 		if err != nil {
-			log4.Error("TestGetVbftInfo initVbftBlock error:%s", err)
-			return false
+			return result, fmt.Errorf("initVbftBlock error:%s", err)
 		}
 		if blk.Info.NewChainConfig == nil {
-			log4.Error("TestGetVbftInfo newchainconfig error:%s", err)
-			return false
+			return result, fmt.Errorf("newchainconfig of block %d is nil", block.Info.LastConfigBlockNum)
 		}
 		cfg = *blk.Info.NewChainConfig
 	}
-	fmt.Printf("block vbft chainConfig, View:%d, N:%d, C:%d, BlockMsgDelay:, HashMsgDelay:, PeerHandshakeTimeout:, MaxBlockChangeView:%d, PosTable:\n",
-		cfg.View, cfg.N, cfg.C, cfg.BlockMsgDelay, cfg.HashMsgDelay, cfg.PeerHandshakeTimeout, cfg.MaxBlockChangeView, cfg.PosTable)
-	for _, p := range cfg.Peers {
-		fmt.Printf("peerInfo Index: %d, ID:\n", p.Index, p.ID)
-	}
-	return true
+	result.AddOutput("chainConfig", &cfg)
+	return result, nil
 }
 
 type MultiTransferParam struct {
@@ -1902,163 +1744,125 @@ type MultiTransferParam struct {
 	Amount    []uint64
 }
 
-func MultiTransferOnt(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/MultiTransferOnt.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func MultiTransferOnt(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	multiTransferParam := new(MultiTransferParam)
-	err = json.Unmarshal(data, multiTransferParam)
+	err := env.Params.Load(multiTransferParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
 	time.Sleep(1 * time.Second)
-	for _, path := range multiTransferParam.FromPath {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
+	users, _, err := getAccounts(ctx, env.Sdk, multiTransferParam.FromPath)
+	if err != nil {
+		return result, err
 	}
-	ok := multiTransfer(ontSdk, utils.OntContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
-	if !ok {
-		return false
+	to, err := parseAddresses(multiTransferParam.ToAddress)
+	if err != nil {
+		return result, err
 	}
-	common.WaitForBlock(ontSdk)
-	return true
-}
-
-func MultiTransferOng(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/MultiTransferOng.json")
+	txHash, err := client.NewOntClient(env.Chain, env.ClientOptions()).MultiTransfer(users, to, multiTransferParam.Amount)
 	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
+		return result, fmt.Errorf("MultiTransfer error:%s", err)
 	}
+	result.AddTxHash(txHash)
+	return result, nil
+}
+
+func MultiTransferOng(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	multiTransferParam := new(MultiTransferParam)
-	err = json.Unmarshal(data, multiTransferParam)
+	err := env.Params.Load(multiTransferParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	var users []*sdk.Account
 	time.Sleep(1 * time.Second)
-	for _, path := range multiTransferParam.FromPath {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
+	users, _, err := getAccounts(ctx, env.Sdk, multiTransferParam.FromPath)
+	if err != nil {
+		return result, err
 	}
-	ok := multiTransfer(ontSdk, utils.OngContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
-	if !ok {
-		return false
+	to, err := parseAddresses(multiTransferParam.ToAddress)
+	if err != nil {
+		return result, err
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	txHash, err := client.NewOngClient(env.Chain, env.ClientOptions()).MultiTransfer(users, to, multiTransferParam.Amount)
+	if err != nil {
+		return result, fmt.Errorf("MultiTransfer error:%s", err)
+	}
+	result.AddTxHash(txHash)
+	return result, nil
 }
 
 type GetAttributesParam struct {
 	PeerPubkey string
 }
 
-func GetAttributes(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAttributes.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAttributes(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getAttributesParam := new(GetAttributesParam)
-	err = json.Unmarshal(data, getAttributesParam)
+	err := env.Params.Load(getAttributesParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	peerAttributes, err := getAttributes(ontSdk, getAttributesParam.PeerPubkey)
+	peerAttributes, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetAttributes(getAttributesParam.PeerPubkey)
 	if err != nil {
-		log4.Error("getAttributes failed ", err)
-		return false
+		return result, fmt.Errorf("GetAttributes error:%s", err)
 	}
-	fmt.Println("peerAttributes.PeerPubkey is:", peerAttributes.PeerPubkey)
-	fmt.Println("peerAttributes.MaxAuthorize is:", peerAttributes.MaxAuthorize)
-	fmt.Println("peerAttributes.T2PeerCost is:", peerAttributes.T2PeerCost)
-	fmt.Println("peerAttributes.T1PeerCost is:", peerAttributes.T1PeerCost)
-	fmt.Println("peerAttributes.TPeerCost is:", peerAttributes.TPeerCost)
-	fmt.Println("peerAttributes.T2StakeCost is:", peerAttributes.T2StakeCost)
-	fmt.Println("peerAttributes.T1StakeCost is:", peerAttributes.T1StakeCost)
-	fmt.Println("peerAttributes.TStakeCost is:", peerAttributes.TStakeCost)
+	result.AddOutput("peerAttributes", peerAttributes)
 
-	return true
+	return result, nil
 }
 
 type GetSplitFeeAddressParam struct {
 	Address string
 }
 
-func GetSplitFeeAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetSplitFeeAddress.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetSplitFeeAddress(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getSplitFeeAddressParam := new(GetSplitFeeAddressParam)
-	err = json.Unmarshal(data, getSplitFeeAddressParam)
+	err := env.Params.Load(getSplitFeeAddressParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
 	address, err := ocommon.AddressFromBase58(getSplitFeeAddressParam.Address)
 	if err != nil {
-		log4.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
 	}
-	splitFeeAddress, err := getSplitFeeAddress(ontSdk, address)
+	splitFeeAddress, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetSplitFeeAddress(address)
 	if err != nil {
-		log4.Error("getSplitFeeAddress failed ", err)
-		return false
+		return result, fmt.Errorf("GetSplitFeeAddress error:%s", err)
 	}
-	fmt.Println("splitFeeAddress.Address is:", splitFeeAddress.Address)
-	fmt.Println("splitFeeAddress.Amount is:", splitFeeAddress.Amount)
+	result.AddOutput("splitFeeAddress", splitFeeAddress)
 
-	return true
+	return result, nil
 }
 
-func GetSplitFee(ontSdk *sdk.OntologySdk) bool {
-	splitFee, err := getSplitFee(ontSdk)
+func GetSplitFee(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	splitFee, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetSplitFee()
 	if err != nil {
-		log4.Error("getSplitFeeAddress failed ", err)
-		return false
+		return result, fmt.Errorf("GetSplitFee error:%s", err)
 	}
-	fmt.Println("splitFee is:", splitFee)
+	result.AddOutput("splitFee", splitFee)
 
-	return true
+	return result, nil
 }
 
 type GetPromisePosParam struct {
 	PeerPubkey string
 }
 
-func GetPromisePos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPromisePos.json")
-	if err != nil {
-		log4.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPromisePos(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
 	getPromisePosParam := new(GetPromisePosParam)
-	err = json.Unmarshal(data, getPromisePosParam)
+	err := env.Params.Load(getPromisePosParam)
 	if err != nil {
-		log4.Error("json.Unmarshal failed ", err)
-		return false
+		return result, err
 	}
-	promisePos, err := getPromisePos(ontSdk, getPromisePosParam.PeerPubkey)
+	promisePos, err := client.NewGovernanceClient(env.Chain, env.ClientOptions()).GetPromisePos(getPromisePosParam.PeerPubkey)
 	if err != nil {
-		log4.Error("getPromisePos failed ", err)
-		return false
+		return result, fmt.Errorf("GetPromisePos error:%s", err)
 	}
-	fmt.Println("promisePos.PeerPubkey is:", promisePos.PeerPubkey)
-	fmt.Println("promisePos.PromisePos is:", promisePos.PromisePos)
+	result.AddOutput("promisePos", promisePos)
 
-	return true
+	return result, nil
 }
@@ -0,0 +1,435 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ontio/ontology-tool/client"
+	"github.com/ontio/ontology-tool/core"
+	ocommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//SECONDS_PER_YEAR annualize rewards of a round
+const SECONDS_PER_YEAR = 365 * 24 * 3600
+
+//blockTimeSample is the number of recent blocks the block interval is averaged over
+const blockTimeSample = 1000
+
+type RewardForecastParam struct {
+	//Address is an authorizing address or node owner to forecast the reward of, optional
+	Address string
+	//Income is the ONG fee split at the end of a round in 10^-9 ONG, default is the fee collected in the current
+	//view so far, extrapolated to MaxBlockChangeView blocks
+	Income uint64
+	//Round selects the peer costs in effect: 0 for the round of the current view, 1 for the next round, 2 or more
+	//for the costs set last, which hold from then on
+	Round uint32
+	//RoundsPerYear annualize the reward of a round, default is estimated from MaxBlockChangeView and recent blocks
+	RoundsPerYear float64
+}
+
+//roundForecast is the ONG a round splits
+type roundForecast struct {
+	View          uint32
+	CollectedOng  uint64
+	IncomeOng     uint64
+	DappOng       uint64
+	ConsensusOng  uint64
+	CandidateOng  uint64
+	RoundsPerYear float64
+}
+
+//nodeReward is the ONG a node gets in a round and how it is shared between owner and authorizers. Yields are
+//annual ONG per ONT staked
+type nodeReward struct {
+	Rank            int
+	Index           uint32
+	PeerPubkey      string
+	Owner           ocommon.Address
+	Role            string
+	InitPos         uint64
+	TotalPos        uint64
+	PeerCost        uint64
+	StakeCost       uint64
+	NodeOng         uint64
+	OwnerOng        uint64
+	AuthorizeOng    uint64
+	OwnerYield      float64
+	AuthorizeYield  float64
+	authorizeAmount uint64
+	preConsensus    bool
+	consensus       bool
+}
+
+//addressReward is the ONG an address gets from a node in a round
+type addressReward struct {
+	PeerPubkey string
+	As         string
+	Stake      uint64
+	Ong        uint64
+	AnnualOng  uint64
+	Yield      float64
+}
+
+//RewardForecast estimate the fee split of the commitDpos ending a round the way the governance contract splits it:
+//the dapp share, A percent to consensus nodes by the split curve and B percent to candidate nodes by stake, then
+//each node amount between its owner and authorizers by the peer costs
+func RewardForecast(ctx context.Context, env *core.Env) (*core.Result, error) {
+	result := core.NewResult()
+	param := new(RewardForecastParam)
+	err := env.Params.Load(param)
+	if err != nil {
+		return result, err
+	}
+	var address ocommon.Address
+	if param.Address != "" {
+		address, err = ocommon.AddressFromBase58(param.Address)
+		if err != nil {
+			return result, fmt.Errorf("common.AddressFromBase58 error:%s", err)
+		}
+	}
+	gov := client.NewGovernanceClient(env.Chain, env.ClientOptions())
+	governanceView, err := gov.GetGovernanceView()
+	if err != nil {
+		return result, fmt.Errorf("GetGovernanceView error:%s", err)
+	}
+	view := governanceView.View
+	config, err := gov.GetVbftConfig()
+	if err != nil {
+		return result, fmt.Errorf("GetVbftConfig error:%s", err)
+	}
+	globalParam, err := gov.GetGlobalParam()
+	if err != nil {
+		return result, fmt.Errorf("GetGlobalParam error:%s", err)
+	}
+	globalParam2, err := gov.GetGlobalParam2()
+	if err != nil {
+		return result, fmt.Errorf("GetGlobalParam2 error:%s", err)
+	}
+	splitCurve, err := gov.GetSplitCurve()
+	if err != nil {
+		return result, fmt.Errorf("GetSplitCurve error:%s", err)
+	}
+	curPeerPoolMap, err := gov.GetPeerPoolMapOfView(view)
+	if err != nil {
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
+	}
+	//the split is by the stakes of the pool the round started with
+	peerPoolMap, err := gov.GetPeerPoolMapOfView(view - 1)
+	if errors.Is(err, client.ErrViewNotFound) {
+		result.AddWarning(fmt.Sprintf("peer pool of view %d not found, stakes of view %d are used", view-1, view))
+		peerPoolMap = curPeerPoolMap
+	} else if err != nil {
+		return result, fmt.Errorf("GetPeerPoolMap error:%s", err)
+	}
+
+	height, err := env.Chain.GetCurrentBlockHeight()
+	if err != nil {
+		return result, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	round := &roundForecast{View: view, RoundsPerYear: param.RoundsPerYear}
+	round.CollectedOng, err = collectedFee(gov, env)
+	if err != nil {
+		return result, err
+	}
+	round.IncomeOng = param.Income
+	if round.IncomeOng == 0 {
+		round.IncomeOng = round.CollectedOng
+		if height > governanceView.Height {
+			round.IncomeOng = mulDiv(round.CollectedOng, uint64(config.MaxBlockChangeView), uint64(height-governanceView.Height))
+		}
+	}
+	if round.RoundsPerYear == 0 {
+		round.RoundsPerYear, err = estimateRoundsPerYear(env, height, config.MaxBlockChangeView)
+		if err != nil {
+			return result, err
+		}
+	}
+	gasAddress, err := gov.GetGasAddress()
+	if err != nil {
+		return result, fmt.Errorf("GetGasAddress error:%s", err)
+	}
+	if gasAddress != ocommon.ADDRESS_EMPTY {
+		round.DappOng = mulDiv(round.IncomeOng, uint64(globalParam2.DappFee), 100)
+	}
+	nodeIncome := round.IncomeOng - round.DappOng
+	round.ConsensusOng = mulDiv(nodeIncome, uint64(globalParam.A), 100)
+	round.CandidateOng = mulDiv(nodeIncome, uint64(globalParam.B), 100)
+
+	var nodes []*nodeReward
+	for _, item := range peerPoolMap.PeerPoolMap {
+		if item.Status != governance.CandidateStatus && item.Status != governance.ConsensusStatus {
+			continue
+		}
+		node := &nodeReward{
+			Index:        item.Index,
+			PeerPubkey:   item.PeerPubkey,
+			Owner:        item.Address,
+			InitPos:      item.InitPos,
+			TotalPos:     item.TotalPos,
+			preConsensus: item.Status == governance.ConsensusStatus,
+		}
+		if cur, ok := curPeerPoolMap.PeerPoolMap[item.PeerPubkey]; ok {
+			node.consensus = cur.Status == governance.ConsensusStatus
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) < int(config.K) {
+		return result, fmt.Errorf("%d candidate and consensus peers are less than K %d", len(nodes), config.K)
+	}
+	//same order as executeSplit2: stake descending, then peer public key descending
+	sort.SliceStable(nodes, func(i, j int) bool {
+		stakeI, stakeJ := nodes[i].InitPos+nodes[i].TotalPos, nodes[j].InitPos+nodes[j].TotalPos
+		if stakeI != stakeJ {
+			return stakeI > stakeJ
+		}
+		return nodes[i].PeerPubkey > nodes[j].PeerPubkey
+	})
+	err = splitNodeIncome(nodes, config.K, globalParam2.CandidateFeeSplitNum, uint64(globalParam.Yita), splitCurve.Yi, round)
+	if err != nil {
+		result.AddWarning(err.Error())
+	}
+
+	addressRewards := make([]*addressReward, 0)
+	for i, node := range nodes {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		node.Rank = i + 1
+		peerAttributes, err := gov.GetAttributes(node.PeerPubkey)
+		if err != nil {
+			return result, fmt.Errorf("GetAttributes error:%s", err)
+		}
+		node.PeerCost, node.StakeCost = peerCosts(peerAttributes, param.Round)
+		node.splitToAuthorizers()
+		node.OwnerYield = annualYield(node.OwnerOng, node.InitPos, round.RoundsPerYear)
+		node.AuthorizeYield = annualYield(node.AuthorizeOng, node.TotalPos, round.RoundsPerYear)
+		if param.Address == "" || node.NodeOng == 0 {
+			continue
+		}
+		if node.Owner == address {
+			addressRewards = append(addressRewards, newAddressReward(node.PeerPubkey, "owner", node.InitPos, node.OwnerOng, round.RoundsPerYear))
+			continue
+		}
+		authorizeInfo, err := gov.GetAuthorizeInfo(node.PeerPubkey, address)
+		if err != nil {
+			return result, fmt.Errorf("GetAuthorizeInfo error:%s", err)
+		}
+		validPos := authorizeInfo.CandidatePos + authorizeInfo.WithdrawCandidatePos
+		if node.preConsensus || node.consensus {
+			validPos = authorizeInfo.ConsensusPos + authorizeInfo.WithdrawConsensusPos
+		}
+		if validPos == 0 || node.TotalPos == 0 {
+			continue
+		}
+		ong := mulDiv(validPos, node.authorizeAmount, node.TotalPos)
+		addressRewards = append(addressRewards, newAddressReward(node.PeerPubkey, "authorizer", validPos, ong, round.RoundsPerYear))
+	}
+
+	result.AddOutput("round", round)
+	result.AddOutput("nodes", nodes)
+	if param.Address != "" {
+		result.AddOutput("address", addressRewards)
+	}
+	return result, nil
+}
+
+//collectedFee return the ONG the governance contract collected since the last split
+func collectedFee(gov *client.GovernanceClient, env *core.Env) (uint64, error) {
+	balance, err := client.NewOngClient(env.Chain, env.ClientOptions()).BalanceOf(utils.GovernanceContractAddress)
+	if err != nil {
+		return 0, fmt.Errorf("BalanceOf governance error:%s", err)
+	}
+	splitFee, err := gov.GetSplitFee()
+	if err != nil {
+		return 0, fmt.Errorf("GetSplitFee error:%s", err)
+	}
+	if balance < splitFee {
+		return 0, fmt.Errorf("ong balance %d of governance is less than split fee %d", balance, splitFee)
+	}
+	return balance - splitFee, nil
+}
+
+//estimateRoundsPerYear return the rounds of a year if every round lasts maxBlockChangeView blocks of the average
+//interval of recent blocks
+func estimateRoundsPerYear(env *core.Env, height uint32, maxBlockChangeView uint32) (float64, error) {
+	sample := uint32(blockTimeSample)
+	if height < sample {
+		sample = height
+	}
+	if sample == 0 || maxBlockChangeView == 0 {
+		return 0, fmt.Errorf("can not estimate rounds per year at height %d, set RoundsPerYear", height)
+	}
+	last, err := env.Chain.GetBlockByHeight(height)
+	if err != nil {
+		return 0, fmt.Errorf("GetBlockByHeight %d error:%s", height, err)
+	}
+	first, err := env.Chain.GetBlockByHeight(height - sample)
+	if err != nil {
+		return 0, fmt.Errorf("GetBlockByHeight %d error:%s", height-sample, err)
+	}
+	interval := float64(last.Header.Timestamp-first.Header.Timestamp) / float64(sample)
+	if interval <= 0 {
+		return 0, fmt.Errorf("can not estimate rounds per year from block timestamps, set RoundsPerYear")
+	}
+	env.Logger.Info("Average block interval %.2fs over %d blocks, a round of %d blocks lasts %.1f days", interval, sample,
+		maxBlockChangeView, interval*float64(maxBlockChangeView)/86400)
+	return math.Round(SECONDS_PER_YEAR/(interval*float64(maxBlockChangeView))*100) / 100, nil
+}
+
+//splitNodeIncome set NodeOng of nodes sorted by stake: the top k nodes share round.ConsensusOng by the split curve,
+//the next ones up to candidateFeeSplitNum nodes share round.CandidateOng by stake
+func splitNodeIncome(nodes []*nodeReward, k uint32, candidateFeeSplitNum uint32, yita uint64, yi []uint32, round *roundForecast) error {
+	var sum uint64
+	for _, node := range nodes[:k] {
+		node.Role = statusName(governance.ConsensusStatus)
+		sum += node.InitPos + node.TotalPos
+	}
+	if sum < uint64(k) {
+		return fmt.Errorf("stake of consensus nodes is 0, the contract does not split")
+	}
+	avg := sum / uint64(k)
+	s := make([]uint64, k)
+	var sumS uint64
+	for i, node := range nodes[:k] {
+		var err error
+		s[i], err = splitCurveValue(yi, node.InitPos+node.TotalPos, avg, yita)
+		if err != nil {
+			return err
+		}
+		sumS += s[i]
+	}
+	if sumS == 0 {
+		return fmt.Errorf("split curve is 0 for all consensus nodes, the contract fails to split")
+	}
+	for i, node := range nodes[:k] {
+		node.NodeOng = mulDiv(round.ConsensusOng, s[i], sumS)
+	}
+
+	length := len(nodes)
+	if int(candidateFeeSplitNum) < length {
+		length = int(candidateFeeSplitNum)
+	}
+	sum = 0
+	for i := int(k); i < length; i++ {
+		nodes[i].Role = statusName(governance.CandidateStatus)
+		sum += nodes[i].InitPos + nodes[i].TotalPos
+	}
+	if sum == 0 {
+		return nil
+	}
+	for i := int(k); i < length; i++ {
+		nodes[i].NodeOng = mulDiv(round.CandidateOng, nodes[i].InitPos+nodes[i].TotalPos, sum)
+	}
+	return nil
+}
+
+//splitCurveValue interpolate the split curve yi at pos relative to the average stake, as splitCurve of the
+//governance contract
+func splitCurveValue(yi []uint32, pos, avg, yita uint64) (uint64, error) {
+	xs := governance.Xi
+	if len(yi) != len(xs) {
+		return 0, fmt.Errorf("split curve has %d points, should be %d", len(yi), len(xs))
+	}
+	xi := governance.PRECISE * yita * 2 * pos / (avg * 10)
+	index := xi / (governance.PRECISE / 10)
+	if index > uint64(len(xs)-2) {
+		index = uint64(len(xs) - 2)
+		xi = uint64(xs[len(xs)-1])
+	}
+	x0, x1 := uint64(xs[index]), uint64(xs[index+1])
+	y0, y1 := uint64(yi[index]), uint64(yi[index+1])
+	return (y1*xi + y0*x1 - y0*xi - y1*x0) / (x1 - x0), nil
+}
+
+//peerCosts return the peer cost and stake cost in effect round rounds later. A stake cost of 0 means the peer cost,
+//101 means 0
+func peerCosts(peerAttributes *governance.PeerAttributes, round uint32) (uint64, uint64) {
+	peerCost, stakeCost := peerAttributes.TPeerCost, peerAttributes.TStakeCost
+	switch {
+	case round == 1:
+		peerCost, stakeCost = peerAttributes.T1PeerCost, peerAttributes.T1StakeCost
+	case round >= 2:
+		peerCost, stakeCost = peerAttributes.T2PeerCost, peerAttributes.T2StakeCost
+	}
+	if stakeCost == 0 {
+		stakeCost = peerCost
+	}
+	if stakeCost == 101 {
+		stakeCost = 0
+	}
+	return peerCost, stakeCost
+}
+
+//splitToAuthorizers share NodeOng between owner and authorizers: the share of authorized stake less StakeCost
+//percent and the share of init pos less PeerCost percent go to authorizers by their pos, the rest to the owner.
+//splitNodeFee of the pinned contract computes the stake fee as NodeOng*TotalPos-(InitPos+TotalPos), a
+//subtraction where the share needs a division, and its result wraps around uint64. The forecast keeps the
+//share NodeOng*TotalPos/(InitPos+TotalPos), so it differs from what that contract pays past the new peer cost
+//height
+func (this *nodeReward) splitToAuthorizers() {
+	stake := this.InitPos + this.TotalPos
+	if this.NodeOng == 0 || stake == 0 {
+		return
+	}
+	stakeFee := mulDiv(this.NodeOng, this.TotalPos, stake)
+	nodeFee := this.NodeOng - stakeFee
+	this.authorizeAmount = stakeFee*(100-this.StakeCost)/100 + nodeFee*(100-this.PeerCost)/100
+	if this.TotalPos > 0 {
+		this.AuthorizeOng = this.authorizeAmount
+	}
+	this.OwnerOng = this.NodeOng - this.AuthorizeOng
+}
+
+func newAddressReward(peerPubkey, as string, pos, ong uint64, roundsPerYear float64) *addressReward {
+	return &addressReward{
+		PeerPubkey: peerPubkey,
+		As:         as,
+		Stake:      pos,
+		Ong:        ong,
+		AnnualOng:  uint64(float64(ong) * roundsPerYear),
+		Yield:      annualYield(ong, pos, roundsPerYear),
+	}
+}
+
+//annualYield return ONG per ONT of pos in a year, ong is in 10^-9 ONG
+func annualYield(ong, pos uint64, roundsPerYear float64) float64 {
+	if pos == 0 {
+		return 0
+	}
+	yield := float64(ong) * roundsPerYear / math.Pow10(core.ONG_DECIMALS) / float64(pos)
+	return math.Round(yield*1e6) / 1e6
+}
+
+//mulDiv return a*b/c without overflow of a*b
+func mulDiv(a, b, c uint64) uint64 {
+	if c == 0 {
+		return 0
+	}
+	value := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	return value.Div(value, new(big.Int).SetUint64(c)).Uint64()
+}
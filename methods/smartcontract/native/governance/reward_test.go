@@ -0,0 +1,266 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+func TestSplitCurveValue(t *testing.T) {
+	tests := []struct {
+		name string
+		yi   []uint32
+		pos  uint64
+		avg  uint64
+		want uint64
+		err  string
+	}{
+		{name: "average stake", yi: testSplitCurve(), pos: 200, avg: 200, want: 10000},
+		{name: "half average stake", yi: testSplitCurve(), pos: 100, avg: 200, want: 5000},
+		{name: "between points", yi: testSplitCurve(), pos: 25, avg: 100, want: 2500},
+		{name: "beyond last point", yi: testSplitCurve(), pos: 100 * 200, avg: 200, want: 100000},
+		{name: "short curve", yi: testSplitCurve()[:100], pos: 200, avg: 200, err: "split curve has 100 points, should be 101"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := splitCurveValue(test.yi, test.pos, test.avg, uint64(testGlobalParam().Yita))
+			checkError(t, err, test.err)
+			if value != test.want {
+				t.Fatalf("split curve value %d, should be %d", value, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitNodeIncome(t *testing.T) {
+	tests := []struct {
+		name                 string
+		stakes               []uint64
+		candidateFeeSplitNum uint32
+		want                 []uint64
+		err                  string
+	}{
+		//average 200: the curve gives 15000 and 5000
+		{name: "consensus and candidate", stakes: []uint64{300, 100, 30, 20}, candidateFeeSplitNum: 4, want: []uint64{750, 250, 300, 200}},
+		{name: "candidates beyond split num", stakes: []uint64{300, 100, 30, 20}, candidateFeeSplitNum: 3, want: []uint64{750, 250, 500, 0}},
+		{name: "no candidate stake", stakes: []uint64{300, 100, 0}, candidateFeeSplitNum: 3, want: []uint64{750, 250, 0}},
+		{name: "no consensus stake", stakes: []uint64{0, 0, 30}, candidateFeeSplitNum: 3, err: "stake of consensus nodes is 0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := make([]*nodeReward, 0, len(test.stakes))
+			for _, stake := range test.stakes {
+				nodes = append(nodes, &nodeReward{InitPos: stake})
+			}
+			round := &roundForecast{ConsensusOng: 1000, CandidateOng: 500}
+			err := splitNodeIncome(nodes, 2, test.candidateFeeSplitNum, uint64(testGlobalParam().Yita), testSplitCurve(), round)
+			checkError(t, err, test.err)
+			if test.err != "" {
+				return
+			}
+			ongs := make([]uint64, 0, len(nodes))
+			for _, node := range nodes {
+				ongs = append(ongs, node.NodeOng)
+			}
+			if !reflect.DeepEqual(ongs, test.want) {
+				t.Fatalf("node ong %v, should be %v", ongs, test.want)
+			}
+			if nodes[0].Role != statusName(governance.ConsensusStatus) || nodes[2].Role != statusName(governance.CandidateStatus) {
+				t.Fatalf("roles %q and %q, should be consensus and candidate", nodes[0].Role, nodes[2].Role)
+			}
+		})
+	}
+}
+
+func TestPeerCosts(t *testing.T) {
+	peerAttributes := &governance.PeerAttributes{
+		TPeerCost:   100,
+		TStakeCost:  0,
+		T1PeerCost:  50,
+		T1StakeCost: 101,
+		T2PeerCost:  30,
+		T2StakeCost: 20,
+	}
+	tests := []struct {
+		round     uint32
+		peerCost  uint64
+		stakeCost uint64
+	}{
+		//stake cost 0 is the peer cost
+		{round: 0, peerCost: 100, stakeCost: 100},
+		//stake cost 101 is 0
+		{round: 1, peerCost: 50, stakeCost: 0},
+		{round: 2, peerCost: 30, stakeCost: 20},
+		{round: 5, peerCost: 30, stakeCost: 20},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("round %d", test.round), func(t *testing.T) {
+			peerCost, stakeCost := peerCosts(peerAttributes, test.round)
+			if peerCost != test.peerCost || stakeCost != test.stakeCost {
+				t.Fatalf("costs %d and %d, should be %d and %d", peerCost, stakeCost, test.peerCost, test.stakeCost)
+			}
+		})
+	}
+}
+
+func TestSplitToAuthorizers(t *testing.T) {
+	tests := []struct {
+		name         string
+		node         *nodeReward
+		ownerOng     uint64
+		authorizeOng uint64
+	}{
+		//stake fee 750 less 20%, node fee 250 less 50%
+		{name: "shared", node: &nodeReward{NodeOng: 1000, InitPos: 100, TotalPos: 300, PeerCost: 50, StakeCost: 20},
+			ownerOng: 275, authorizeOng: 725},
+		{name: "owner takes all", node: &nodeReward{NodeOng: 1000, InitPos: 100, TotalPos: 300, PeerCost: 100, StakeCost: 100},
+			ownerOng: 1000, authorizeOng: 0},
+		{name: "no authorization", node: &nodeReward{NodeOng: 1000, InitPos: 100, PeerCost: 50, StakeCost: 20},
+			ownerOng: 1000, authorizeOng: 0},
+		{name: "no income", node: &nodeReward{InitPos: 100, TotalPos: 300, PeerCost: 50, StakeCost: 20}},
+		//stake fee is the authorized share 250, not 1000*100-(300+100) of the pinned contract
+		{name: "stake fee by share of stake", node: &nodeReward{NodeOng: 1000, InitPos: 300, TotalPos: 100, PeerCost: 100, StakeCost: 0},
+			ownerOng: 750, authorizeOng: 250},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.node.splitToAuthorizers()
+			if test.node.OwnerOng != test.ownerOng || test.node.AuthorizeOng != test.authorizeOng {
+				t.Fatalf("owner %d authorizers %d, should be %d and %d", test.node.OwnerOng, test.node.AuthorizeOng,
+					test.ownerOng, test.authorizeOng)
+			}
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		a, b, c uint64
+		want    uint64
+	}{
+		{a: 10, b: 3, c: 4, want: 7},
+		{a: math.MaxUint64, b: 2, c: 4, want: math.MaxUint64 / 2},
+		{a: 10, b: 3, c: 0, want: 0},
+	}
+	for _, test := range tests {
+		if value := mulDiv(test.a, test.b, test.c); value != test.want {
+			t.Fatalf("mulDiv(%d, %d, %d) is %d, should be %d", test.a, test.b, test.c, value, test.want)
+		}
+	}
+}
+
+func TestAnnualYield(t *testing.T) {
+	tests := []struct {
+		ong           uint64
+		pos           uint64
+		roundsPerYear float64
+		want          float64
+	}{
+		{ong: 1000000000, pos: 10, roundsPerYear: 10, want: 1},
+		{ong: 1, pos: 3, roundsPerYear: 1, want: 0},
+		{ong: 2000000000, pos: 3, roundsPerYear: 1, want: 0.666667},
+		{ong: 1000000000, pos: 0, roundsPerYear: 10, want: 0},
+	}
+	for _, test := range tests {
+		if yield := annualYield(test.ong, test.pos, test.roundsPerYear); yield != test.want {
+			t.Fatalf("annualYield(%d, %d, %v) is %v, should be %v", test.ong, test.pos, test.roundsPerYear, yield, test.want)
+		}
+	}
+}
+
+func TestRewardForecast(t *testing.T) {
+	pool := newTestPool()
+	authorizer := sdk.NewAccount().Address
+	tests := []struct {
+		name   string
+		params string
+		//authorizeOng of the peer of index 8, which has costs set
+		authorizeOng uint64
+		//ong of the authorizer
+		ong uint64
+	}{
+		//income split 300 ONG to the peer: stake fee 100 less 20%, node fee 200 less 50%
+		{name: "income of round", params: `{"Income":1000000000000,"RoundsPerYear":10}`,
+			authorizeOng: 180000000000, ong: 90000000000},
+		{name: "collected fee", params: `{"RoundsPerYear":10}`,
+			authorizeOng: 180000000000, ong: 90000000000},
+		//stake cost 101 is 0, so node fee 200 is not shared
+		{name: "costs of next round", params: `{"Income":1000000000000,"RoundsPerYear":10,"Round":1}`,
+			authorizeOng: 100000000000, ong: 50000000000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestChain(t, pool)
+			chain.SetBalance(utils.OngContractAddress, utils.GovernanceContractAddress, 1000000000000)
+			err := chain.SetAttributes(&governance.PeerAttributes{
+				PeerPubkey:  pool[7].PeerPubkey,
+				TPeerCost:   50,
+				TStakeCost:  20,
+				T1PeerCost:  100,
+				T1StakeCost: 101,
+			})
+			if err != nil {
+				t.Fatalf("SetAttributes error:%s", err)
+			}
+			err = chain.SetAuthorizeInfo(&governance.AuthorizeInfo{PeerPubkey: pool[7].PeerPubkey, Address: authorizer, CandidatePos: 5000})
+			if err != nil {
+				t.Fatalf("SetAuthorizeInfo error:%s", err)
+			}
+			params := fmt.Sprintf(`{"Address":"%s",%s`, authorizer.ToBase58(), test.params[1:])
+			result, err := RewardForecast(context.Background(), newTestEnv(chain, params))
+			checkError(t, err, "")
+			if len(result.Warnings) != 0 {
+				t.Fatalf("unexpected warnings %q", result.Warnings)
+			}
+
+			round := outputOf(result, "round").(*roundForecast)
+			if round.IncomeOng != 1000000000000 || round.ConsensusOng != 500000000000 || round.CandidateOng != 500000000000 {
+				t.Fatalf("round %+v, should split 1000 ONG half and half", round)
+			}
+			nodes := outputOf(result, "nodes").([]*nodeReward)
+			if len(nodes) != 9 {
+				t.Fatalf("%d nodes, should be the 9 candidate and consensus peers", len(nodes))
+			}
+			var consensusOng uint64
+			for _, node := range nodes[:7] {
+				consensusOng += node.NodeOng
+			}
+			if consensusOng > round.ConsensusOng || consensusOng < round.ConsensusOng-7 {
+				t.Fatalf("consensus nodes get %d, should share %d", consensusOng, round.ConsensusOng)
+			}
+			node := nodes[7]
+			if node.Index != 8 || node.NodeOng != 300000000000 || node.AuthorizeOng != test.authorizeOng ||
+				node.OwnerOng != node.NodeOng-test.authorizeOng {
+				t.Fatalf("node %+v, should get 300 ONG and share %d", node, test.authorizeOng)
+			}
+			addressRewards := outputOf(result, "address").([]*addressReward)
+			if len(addressRewards) != 1 || addressRewards[0].As != "authorizer" || addressRewards[0].Ong != test.ong {
+				t.Fatalf("address rewards %+v, should be %d as authorizer of node 8", addressRewards, test.ong)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/core"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//updateDiff is a field of a governance parameter, its live value on chain and the value it is updated to
+type updateDiff struct {
+	Field  string
+	Live   uint64
+	New    uint64
+	Change string
+}
+
+func newUpdateDiff(field string, live, update uint64) *updateDiff {
+	diff := &updateDiff{
+		Field: field,
+		Live:  live,
+		New:   update,
+	}
+	if update > live {
+		diff.Change = fmt.Sprintf("+%d", update-live)
+	} else if update < live {
+		diff.Change = fmt.Sprintf("-%d", live-update)
+	}
+	return diff
+}
+
+//diffFields return a diff of every unsigned integer field of two structs of the same type
+func diffFields(live, update interface{}) []*updateDiff {
+	liveValue := reflect.Indirect(reflect.ValueOf(live))
+	updateValue := reflect.Indirect(reflect.ValueOf(update))
+	diffs := make([]*updateDiff, 0, liveValue.NumField())
+	for i := 0; i < liveValue.NumField(); i++ {
+		switch liveValue.Field(i).Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			diffs = append(diffs, newUpdateDiff(liveValue.Type().Field(i).Name, liveValue.Field(i).Uint(), updateValue.Field(i).Uint()))
+		}
+	}
+	return diffs
+}
+
+//diffSplitCurve return a diff of the points of the split curve which change
+func diffSplitCurve(live, update []uint32) []*updateDiff {
+	diffs := make([]*updateDiff, 0)
+	for i := 0; i < len(live) || i < len(update); i++ {
+		var liveY, updateY uint64
+		if i < len(live) {
+			liveY = uint64(live[i])
+		}
+		if i < len(update) {
+			updateY = uint64(update[i])
+		}
+		if liveY != updateY {
+			diffs = append(diffs, newUpdateDiff(fmt.Sprintf("Yi[%d]", i), liveY, updateY))
+		}
+	}
+	return diffs
+}
+
+//checkConfig return the rules of updateConfig the configuration breaks. candidateNum is the number of candidate
+//and consensus nodes in the current view
+func checkConfig(conf *governance.Configuration, candidateNum int, globalParam *governance.GlobalParam) []string {
+	violations := make([]string, 0)
+	if conf.C == 0 {
+		violations = append(violations, "C can not be 0")
+	}
+	if int(conf.K) > candidateNum {
+		violations = append(violations, fmt.Sprintf("K %d can not be more than the %d candidate and consensus nodes", conf.K, candidateNum))
+	}
+	if conf.K == 0 || conf.L < 16*conf.K || conf.L%conf.K != 0 {
+		violations = append(violations, fmt.Sprintf("L %d must be at least 16*K and a multiple of K %d", conf.L, conf.K))
+	}
+	if conf.K < 2*conf.C+1 {
+		violations = append(violations, fmt.Sprintf("K %d can not be less than 2*C+1 %d", conf.K, 2*conf.C+1))
+	}
+	if 4*conf.K > globalParam.CandidateNum {
+		violations = append(violations, fmt.Sprintf("4*K %d can not be more than CandidateNum %d", 4*conf.K, globalParam.CandidateNum))
+	}
+	if conf.N < conf.K || conf.K < 7 {
+		violations = append(violations, fmt.Sprintf("N %d and K %d do not match N >= K >= 7", conf.N, conf.K))
+	}
+	if conf.BlockMsgDelay < 5000 {
+		violations = append(violations, "BlockMsgDelay must >= 5000")
+	}
+	if conf.HashMsgDelay < 5000 {
+		violations = append(violations, "HashMsgDelay must >= 5000")
+	}
+	if conf.PeerHandshakeTimeout < 10 {
+		violations = append(violations, "PeerHandshakeTimeout must >= 10")
+	}
+	if conf.MaxBlockChangeView < 10000 {
+		violations = append(violations, "MaxBlockChangeView must >= 10000")
+	}
+	return violations
+}
+
+//checkGlobalParam return the rules of updateGlobalParam the global param breaks, K is of the vbft config in effect
+func checkGlobalParam(globalParam *governance.GlobalParam, k uint32) []string {
+	violations := make([]string, 0)
+	if globalParam.A+globalParam.B != 100 {
+		violations = append(violations, fmt.Sprintf("A %d + B %d must equal to 100", globalParam.A, globalParam.B))
+	}
+	if globalParam.Yita == 0 {
+		violations = append(violations, "Yita must > 0")
+	}
+	if globalParam.Penalty > 100 {
+		violations = append(violations, "Penalty must <= 100")
+	}
+	if globalParam.PosLimit < 1 {
+		violations = append(violations, "PosLimit must >= 1")
+	}
+	if globalParam.CandidateNum < 4*k {
+		violations = append(violations, fmt.Sprintf("CandidateNum %d must >= 4*K %d", globalParam.CandidateNum, 4*k))
+	}
+	if globalParam.CandidateFee != 0 && globalParam.CandidateFee < governance.MIN_CANDIDATE_FEE {
+		violations = append(violations, fmt.Sprintf("CandidateFee must be 0 or >= %d", governance.MIN_CANDIDATE_FEE))
+	}
+	if globalParam.MinInitStake < 1 {
+		violations = append(violations, "MinInitStake must >= 1")
+	}
+	return violations
+}
+
+//checkGlobalParam2 return the rules of updateGlobalParam2 the global param 2 breaks. K is of the vbft config in
+//effect, height is the current block height
+func checkGlobalParam2(globalParam2 *governance.GlobalParam2, k uint32, height uint32) []string {
+	violations := make([]string, 0)
+	if height+1 < governance.NEW_VERSION_BLOCK {
+		violations = append(violations, fmt.Sprintf("updateGlobalParam2 is enabled at block %d, chain is at %d",
+			governance.NEW_VERSION_BLOCK, height))
+	}
+	if globalParam2.CandidateFeeSplitNum < k {
+		violations = append(violations, fmt.Sprintf("CandidateFeeSplitNum %d can not be less than K %d", globalParam2.CandidateFeeSplitNum, k))
+	}
+	return violations
+}
+
+//checkSplitCurve return what is wrong with a split curve. The contract reads Yi at the points of Xi, so it needs one
+//value per point, and commitDpos fails if they are all 0
+func checkSplitCurve(yi []uint32) []string {
+	violations := make([]string, 0)
+	if len(yi) != len(governance.Xi) {
+		violations = append(violations, fmt.Sprintf("Yi has %d points, the curve needs %d, one per Xi", len(yi), len(governance.Xi)))
+	}
+	allZero := true
+	for _, y := range yi {
+		if y != 0 {
+			allZero = false
+			break
+		}
+	}
+	if allZero {
+		violations = append(violations, "Yi can not be all 0")
+	}
+	return violations
+}
+
+//splitCurveShape return a warning if Yi rises again after falling from a peak, empty if it does not. The contract
+//takes any curve, but unlike the curve at genesis a node can then get less for a higher stake below the last peak
+func splitCurveShape(yi []uint32) string {
+	peak := -1
+	for i := 1; i < len(yi); i++ {
+		if yi[i] < yi[i-1] && peak < 0 {
+			peak = i - 1
+		}
+		if yi[i] > yi[i-1] && peak >= 0 {
+			return fmt.Sprintf("Yi rises again at point %d after its peak at point %d, unlike the curve at genesis a node can get less for a higher stake", i, peak)
+		}
+	}
+	return ""
+}
+
+//rejectUpdate return error listing the rules broken by the params of method, nil if there is none
+func rejectUpdate(method string, violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s params rejected, the governance contract would fail: %s", method, strings.Join(violations, "; "))
+}
+
+//confirmUpdate add the diff of a governance parameter against its live value to result, and print it and ask the
+//user to type yes before the update is sent. It fails if nothing changes. Nothing is asked if the transaction is
+//not sent, in dry run or export, or if the params are confirmed already
+func confirmUpdate(env *core.Env, result *core.Result, name string, diffs []*updateDiff, confirmed bool) error {
+	changed := 0
+	for _, diff := range diffs {
+		if diff.Change != "" {
+			changed++
+			env.Logger.Info("%s %s: %d -> %d", name, diff.Field, diff.Live, diff.New)
+		}
+	}
+	if changed == 0 {
+		return fmt.Errorf("%s equals the live value, nothing to update", name)
+	}
+	result.AddOutput("diff", diffs)
+	if confirmed || common.TxNotSent() {
+		return nil
+	}
+	err := core.RenderOutputs(os.Stderr, config.OUTPUT_TABLE, []*core.Output{{Name: name, Value: diffs}})
+	if err != nil {
+		return fmt.Errorf("render diff error:%s", err)
+	}
+	prompt := fmt.Sprintf("%d values of %s change, type yes to continue:", changed, name)
+	err = common.ConfirmTyped(prompt, "yes")
+	if err != nil {
+		return fmt.Errorf("%s update not confirmed: %s", name, err)
+	}
+	return nil
+}
+
+//countCandidates return the number of candidate and consensus nodes in the peer pool of the current view
+func countCandidates(peerPoolMap *governance.PeerPoolMap) int {
+	num := 0
+	for _, peerPoolItem := range peerPoolMap.PeerPoolMap {
+		if peerPoolItem.Status == governance.CandidateStatus || peerPoolItem.Status == governance.ConsensusStatus {
+			num++
+		}
+	}
+	return num
+}
@@ -0,0 +1,295 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology-tool/core"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//checkViolations fail t if violations do not include want, or are not empty when want is empty
+func checkViolations(t *testing.T, violations []string, want string) {
+	t.Helper()
+	if want == "" && len(violations) != 0 {
+		t.Fatalf("unexpected violations: %s", strings.Join(violations, "; "))
+	}
+	if want != "" && !strings.Contains(strings.Join(violations, "; "), want) {
+		t.Fatalf("violations %q, should include %q", violations, want)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     func(conf *governance.Configuration)
+		candidates int
+		want       string
+	}{
+		{name: "genesis config", update: func(conf *governance.Configuration) {}, candidates: 9},
+		{name: "C 0", update: func(conf *governance.Configuration) { conf.C = 0 }, candidates: 9, want: "C can not be 0"},
+		{name: "K more than candidates", update: func(conf *governance.Configuration) {}, candidates: 6,
+			want: "K 7 can not be more than the 6 candidate and consensus nodes"},
+		{name: "L not a multiple of K", update: func(conf *governance.Configuration) { conf.L = 113 }, candidates: 9,
+			want: "L 113 must be at least 16*K and a multiple of K 7"},
+		{name: "K less than 2*C+1", update: func(conf *governance.Configuration) { conf.C = 4 }, candidates: 9,
+			want: "K 7 can not be less than 2*C+1 9"},
+		{name: "4*K more than CandidateNum", update: func(conf *governance.Configuration) { conf.N, conf.K, conf.L = 13, 13, 208 },
+			candidates: 13, want: "4*K 52 can not be more than CandidateNum 49"},
+		{name: "N less than K", update: func(conf *governance.Configuration) { conf.N = 6 }, candidates: 9,
+			want: "N 6 and K 7 do not match N >= K >= 7"},
+		{name: "short MaxBlockChangeView", update: func(conf *governance.Configuration) { conf.MaxBlockChangeView = 9999 },
+			candidates: 9, want: "MaxBlockChangeView must >= 10000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := testConfig()
+			test.update(conf)
+			checkViolations(t, checkConfig(conf, test.candidates, testGlobalParam()), test.want)
+		})
+	}
+}
+
+func TestCheckGlobalParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(globalParam *governance.GlobalParam)
+		want   string
+	}{
+		{name: "genesis global param", update: func(globalParam *governance.GlobalParam) {}},
+		{name: "A+B not 100", update: func(globalParam *governance.GlobalParam) { globalParam.B = 40 }, want: "A 50 + B 40 must equal to 100"},
+		{name: "Yita 0", update: func(globalParam *governance.GlobalParam) { globalParam.Yita = 0 }, want: "Yita must > 0"},
+		{name: "Penalty over 100", update: func(globalParam *governance.GlobalParam) { globalParam.Penalty = 101 }, want: "Penalty must <= 100"},
+		{name: "CandidateNum less than 4*K", update: func(globalParam *governance.GlobalParam) { globalParam.CandidateNum = 27 },
+			want: "CandidateNum 27 must >= 4*K 28"},
+		{name: "CandidateFee less than 1 ONG", update: func(globalParam *governance.GlobalParam) { globalParam.CandidateFee = 1 },
+			want: "CandidateFee must be 0 or >= 1000000000"},
+		{name: "CandidateFee 0", update: func(globalParam *governance.GlobalParam) { globalParam.CandidateFee = 0 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			globalParam := testGlobalParam()
+			test.update(globalParam)
+			checkViolations(t, checkGlobalParam(globalParam, testConfig().K), test.want)
+		})
+	}
+}
+
+func TestCheckGlobalParam2(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(globalParam2 *governance.GlobalParam2)
+		height uint32
+		want   string
+	}{
+		{name: "valid", update: func(globalParam2 *governance.GlobalParam2) {}, height: governance.NEW_VERSION_BLOCK},
+		{name: "before new version block", update: func(globalParam2 *governance.GlobalParam2) {}, height: 100,
+			want: "updateGlobalParam2 is enabled at block 414100, chain is at 100"},
+		{name: "CandidateFeeSplitNum less than K", update: func(globalParam2 *governance.GlobalParam2) { globalParam2.CandidateFeeSplitNum = 6 },
+			height: governance.NEW_VERSION_BLOCK, want: "CandidateFeeSplitNum 6 can not be less than K 7"},
+		{name: "MinAuthorizePos 0 left to the contract", update: func(globalParam2 *governance.GlobalParam2) { globalParam2.MinAuthorizePos = 0 },
+			height: governance.NEW_VERSION_BLOCK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			globalParam2 := testGlobalParam2()
+			test.update(globalParam2)
+			checkViolations(t, checkGlobalParam2(globalParam2, testConfig().K, test.height), test.want)
+		})
+	}
+}
+
+func TestCheckSplitCurve(t *testing.T) {
+	peaked := testSplitCurve()
+	for i := 50; i < len(peaked); i++ {
+		peaked[i] = peaked[100-i]
+	}
+	risesAgain := append([]uint32{}, peaked...)
+	risesAgain[80] = risesAgain[79] + 1
+	tests := []struct {
+		name string
+		yi   []uint32
+		want string
+	}{
+		{name: "rising curve", yi: testSplitCurve()},
+		{name: "single peak", yi: peaked},
+		{name: "short curve", yi: peaked[:100], want: "Yi has 100 points, the curve needs 101, one per Xi"},
+		{name: "rises after peak left to the contract", yi: risesAgain},
+		{name: "all zero", yi: make([]uint32, len(governance.Xi)), want: "Yi can not be all 0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkViolations(t, checkSplitCurve(test.yi), test.want)
+		})
+	}
+}
+
+func TestSplitCurveShape(t *testing.T) {
+	peaked := testSplitCurve()
+	for i := 50; i < len(peaked); i++ {
+		peaked[i] = peaked[100-i]
+	}
+	risesAgain := append([]uint32{}, peaked...)
+	risesAgain[80] = risesAgain[79] + 1
+	tests := []struct {
+		name string
+		yi   []uint32
+		want string
+	}{
+		{name: "rising curve", yi: testSplitCurve()},
+		{name: "single peak", yi: peaked},
+		{name: "rises after peak", yi: risesAgain,
+			want: "Yi rises again at point 80 after its peak at point 50, unlike the curve at genesis a node can get less for a higher stake"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if warning := splitCurveShape(test.yi); warning != test.want {
+				t.Fatalf("warning %q, should be %q", warning, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	update := testGlobalParam()
+	update.A, update.B, update.Yita = 60, 40, 5
+	want := []*updateDiff{
+		{Field: "CandidateFee", Live: 500000000000, New: 500000000000},
+		{Field: "MinInitStake", Live: 10000, New: 10000},
+		{Field: "CandidateNum", Live: 49, New: 49},
+		{Field: "PosLimit", Live: 20, New: 20},
+		{Field: "A", Live: 50, New: 60, Change: "+10"},
+		{Field: "B", Live: 50, New: 40, Change: "-10"},
+		{Field: "Yita", Live: 5, New: 5},
+		{Field: "Penalty", Live: 5, New: 5},
+	}
+	diffs := diffFields(testGlobalParam(), update)
+	if !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("diffs %+v, should be %+v", diffs, want)
+	}
+}
+
+func TestDiffSplitCurve(t *testing.T) {
+	tests := []struct {
+		name   string
+		live   []uint32
+		update []uint32
+		want   []*updateDiff
+	}{
+		{name: "same", live: []uint32{1, 2}, update: []uint32{1, 2}, want: []*updateDiff{}},
+		{name: "changed point", live: []uint32{1, 2}, update: []uint32{1, 5},
+			want: []*updateDiff{{Field: "Yi[1]", Live: 2, New: 5, Change: "+3"}}},
+		{name: "shorter", live: []uint32{1, 2}, update: []uint32{1},
+			want: []*updateDiff{{Field: "Yi[1]", Live: 2, New: 0, Change: "-2"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diffs := diffSplitCurve(test.live, test.update)
+			if !reflect.DeepEqual(diffs, test.want) {
+				t.Fatalf("diffs %+v, should be %+v", diffs, test.want)
+			}
+		})
+	}
+}
+
+func TestConfirmUpdate(t *testing.T) {
+	tests := []struct {
+		name  string
+		diffs []*updateDiff
+		err   string
+	}{
+		{name: "nothing changes", diffs: []*updateDiff{newUpdateDiff("A", 50, 50)},
+			err: "globalParam equals the live value, nothing to update"},
+		{name: "confirmed change", diffs: []*updateDiff{newUpdateDiff("A", 50, 60), newUpdateDiff("B", 50, 40)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := core.NewResult()
+			err := confirmUpdate(newTestEnv(nil, "{}"), result, "globalParam", test.diffs, true)
+			checkError(t, err, test.err)
+			if test.err != "" {
+				return
+			}
+			if diffs := outputOf(result, "diff"); !reflect.DeepEqual(diffs, test.diffs) {
+				t.Fatalf("output diff %+v, should be %+v", diffs, test.diffs)
+			}
+		})
+	}
+}
+
+//TestUpdateRejected run update methods whose params fail before any wallet is opened
+func TestUpdateRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		method core.Method
+		params string
+		err    string
+	}{
+		{
+			name:   "update config C 0",
+			method: UpdateConfig,
+			params: `{"N":7,"C":0,"K":7,"L":112,"BlockMsgDelay":10000,"HashMsgDelay":10000,"PeerHandshakeTimeout":10,"MaxBlockChangeView":120000}`,
+			err:    "UpdateConfig params rejected, the governance contract would fail: C can not be 0",
+		},
+		{
+			name:   "update config K more than candidates",
+			method: UpdateConfig,
+			params: `{"N":13,"C":4,"K":13,"L":208,"BlockMsgDelay":10000,"HashMsgDelay":10000,"PeerHandshakeTimeout":10,"MaxBlockChangeView":120000}`,
+			err:    "K 13 can not be more than the 9 candidate and consensus nodes",
+		},
+		{
+			name:   "update config to the live config",
+			method: UpdateConfig,
+			params: `{"N":7,"C":2,"K":7,"L":112,"BlockMsgDelay":10000,"HashMsgDelay":10000,"PeerHandshakeTimeout":10,"MaxBlockChangeView":120000,"Confirmed":true}`,
+			err:    "vbftConfig equals the live value, nothing to update",
+		},
+		{
+			name:   "update global param A+B",
+			method: UpdateGlobalParam,
+			params: `{"CandidateFee":500000000000,"MinInitStake":10000,"CandidateNum":49,"PosLimit":20,"A":60,"B":50,"Yita":5,"Penalty":5}`,
+			err:    "A 60 + B 50 must equal to 100",
+		},
+		{
+			name:   "update global param 2 before new version block",
+			method: UpdateGlobalParam2,
+			params: `{"MinAuthorizePos":500,"CandidateFeeSplitNum":49}`,
+			err:    "updateGlobalParam2 is enabled at block 414100",
+		},
+		{
+			name:   "update split curve all zero",
+			method: UpdateSplitCurve,
+			params: `{"Yi":[0,0]}`,
+			err:    "Yi can not be all 0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestChain(t, newTestPool())
+			_, err := test.method(context.Background(), newTestEnv(chain, test.params))
+			checkError(t, err, test.err)
+			if len(chain.Sent()) != 0 {
+				t.Fatalf("rejected update sent")
+			}
+		})
+	}
+}